@@ -36,11 +36,154 @@ type PensionInput struct {
 	High3Salary           float64 `json:"high3Salary"`
 	YearsOfService        float64 `json:"yearsOfService"`
 	AgeAtRetirement       int     `json:"ageAtRetirement"`
+	AgeAtRetirementMonths int     `json:"ageAtRetirementMonths"` // Additional months of age (0-11) for the CSRS under-55 reduction
 	UnusedSickLeaveMonths int     `json:"unusedSickLeaveMonths"`
-	SurvivorBenefitOption string  `json:"survivorBenefitOption"`
+	UnusedSickLeaveHours  float64 `json:"unusedSickLeaveHours"`  // Converted with OPM's chart; overrides months
+	SickLeaveSchedule     string  `json:"sickLeaveSchedule"`     // "fulltime", "parttime", "firefighter"
+	SickLeaveHoursPerWeek float64 `json:"sickLeaveHoursPerWeek"` // Part-time schedule only
+	SurvivorBenefitOption string  `json:"survivorBenefitOption"` // "full", "partial", "insurableInterest", "none"
+	SurvivorBaseAmount    float64 `json:"survivorBaseAmount"` // CSRS partial election: annual survivor base
 	IsPartTime            bool    `json:"isPartTime"`
 	PartTimeProrationFactor float64 `json:"partTimeProrationFactor"`
 	MilitaryService       int     `json:"militaryService"`
+	MilitaryPeriods       []MilitaryPeriodInput `json:"militaryPeriods"` // Optional: dated military service (overrides MilitaryService)
+	MilitaryDepositPaid   bool    `json:"militaryDepositPaid"` // Military service is credited only once the deposit is paid
+	ServicePeriods        []ServicePeriodInput `json:"servicePeriods"`
+	BirthYear             int     `json:"birthYear"`
+	BirthMonth            int     `json:"birthMonth"`
+	RetirementOption      string  `json:"retirementOption"` // "", "VERA", "DSR", "Disability", "Deferred"
+	AnnuityStartAge       int     `json:"annuityStartAge"`
+	CSRSYearsOfService    float64 `json:"csrsYearsOfService"` // FERS Transferee only
+	FERSYearsOfService    float64 `json:"fersYearsOfService"` // FERS Transferee only
+	TransferDate          string  `json:"transferDate"`       // FERS Transferee with service periods: "YYYY-MM-DD" the FERS election took effect
+	COLARate              float64 `json:"colaRate"`           // Used to project component COLAs
+	ProjectionYears       int     `json:"projectionYears"`    // Years of component COLA to project
+	SSDisabilityBenefit   float64 `json:"ssDisabilityBenefit"` // Disability only: annual SS disability benefit
+	SalaryHistory         []SalaryRateInput `json:"salaryHistory"` // When set, High-3 is computed from this history
+	RetirementDate        string  `json:"retirementDate"`        // "YYYY-MM-DD", end of the High-3 search
+	ProjectedRaisePercent float64 `json:"projectedRaisePercent"` // Assumed January raise after the last salary entry
+	EmployeeContributions float64 `json:"employeeContributions"` // After-tax retirement contributions (IRS Simplified Method)
+	BeneficiaryAge        int     `json:"beneficiaryAge"`        // Survivor annuitant's age at retirement (joint-life divisor; insurable interest reduction)
+	CourtOrder            *CourtOrderInput `json:"courtOrder,omitempty"` // Former spouse award and survivor annuity (COAP)
+	IsSpecialCategory     bool    `json:"isSpecialCategory"`     // LEO, firefighter or air traffic controller: no SRS earnings test before MRA
+	EligibleForSocialSecurity bool `json:"eligibleForSocialSecurity"` // CSRS: military service without a deposit is removed at 62
+}
+
+// CourtOrderInput describes a court order acceptable for processing as entered in the frontend
+type CourtOrderInput struct {
+	AwardType                string  `json:"awardType"`          // "fixed", "percentage", "marital fraction"; empty for none
+	FixedMonthlyAmount       float64 `json:"fixedMonthlyAmount"`
+	Percentage               float64 `json:"percentage"`          // e.g. 0.5 for half
+	MaritalServiceYears      float64 `json:"maritalServiceYears"` // Marital fraction numerator
+	Basis                    string  `json:"basis"`               // "gross" or "net"
+	FixedAmountGetsCOLA      bool    `json:"fixedAmountGetsCola"`
+	FormerSpouseSurvivorBase float64 `json:"formerSpouseSurvivorBase"` // Annual base; 0 for no former spouse survivor annuity
+	HealthPremiums           float64 `json:"healthPremiums"`           // Annual; net basis only
+	LifeInsurancePremiums    float64 `json:"lifeInsurancePremiums"`    // Annual; net basis only
+}
+
+// SalaryRateInput is one dated rate of basic pay as entered in the frontend
+// (dates are "YYYY-MM-DD")
+type SalaryRateInput struct {
+	EffectiveDate   string  `json:"effectiveDate"`
+	Grade           string  `json:"grade"`
+	Step            int     `json:"step"`
+	BasePay         float64 `json:"basePay"`
+	LocalityPercent float64 `json:"localityPercent"`
+	AnnualRate      float64 `json:"annualRate"`
+	Reason          string  `json:"reason"`
+}
+
+// ServicePeriodInput is one dated period of service as entered in the frontend
+// (dates are "YYYY-MM-DD")
+type ServicePeriodInput struct {
+	StartDate             string  `json:"startDate"`
+	EndDate               string  `json:"endDate"`
+	ServiceType           string  `json:"serviceType"`
+	ScheduledHoursPerWeek float64 `json:"scheduledHoursPerWeek"`
+	Refunded              bool    `json:"refunded"`
+	DepositPaid           bool    `json:"depositPaid"`
+	BasicPay              float64 `json:"basicPay"`     // Non-deduction service: basic pay for the deposit
+	RefundAmount          float64 `json:"refundAmount"` // Refunded service: contributions refunded
+	RefundDate            string  `json:"refundDate"`   // Refunded service: "YYYY-MM-DD" (optional)
+}
+
+// DepositItemResult is the deposit or redeposit for one service period and how it was credited
+type DepositItemResult struct {
+	StartDate        string  `json:"startDate"`
+	EndDate          string  `json:"endDate"`
+	Kind             string  `json:"kind"`
+	Paid             bool    `json:"paid"`
+	AmountDue        float64 `json:"amountDue"`
+	Interest         float64 `json:"interest"`
+	Treatment        string  `json:"treatment"`
+	AnnuityReduction float64 `json:"annuityReduction"`
+	IsEstimate       bool    `json:"isEstimate"` // Reduction uses an approximate present value factor
+}
+
+// toDepositItemResults converts model deposit items to the API format
+func toDepositItemResults(items []models.DepositItem) []DepositItemResult {
+	var result []DepositItemResult
+	for _, d := range items {
+		result = append(result, DepositItemResult{
+			StartDate:        d.StartDate.Format("2006-01-02"),
+			EndDate:          d.EndDate.Format("2006-01-02"),
+			Kind:             d.Kind,
+			Paid:             d.Paid,
+			AmountDue:        d.AmountDue,
+			Interest:         d.Interest,
+			Treatment:        d.Treatment,
+			AnnuityReduction: d.AnnuityReduction,
+			IsEstimate:       d.IsEstimate,
+		})
+	}
+	return result
+}
+
+// MilitaryPeriodInput is one period of military service as entered in the frontend
+// (dates are "YYYY-MM-DD")
+type MilitaryPeriodInput struct {
+	StartDate string  `json:"startDate"`
+	EndDate   string  `json:"endDate"`
+	BasicPay  float64 `json:"basicPay"`
+}
+
+// MilitaryDepositInput contains data for the military service deposit calculation
+type MilitaryDepositInput struct {
+	System              string                `json:"system"` // "FERS", "CSRS", "CSRS Offset"
+	Periods             []MilitaryPeriodInput `json:"periods"`
+	CivilianHireDate    string                `json:"civilianHireDate"` // "YYYY-MM-DD"
+	PayoffDate          string                `json:"payoffDate"`       // "YYYY-MM-DD"
+	AssumedInterestRate float64               `json:"assumedInterestRate"`
+	DepositPaid         bool                  `json:"depositPaid"`
+	High3Salary         float64               `json:"high3Salary"`
+	AgeAtRetirement     int                   `json:"ageAtRetirement"`
+	LifeExpectancy      int                   `json:"lifeExpectancy"`
+}
+
+// MilitaryDepositPeriodResult is the deposit due for one period or rate era
+type MilitaryDepositPeriodResult struct {
+	StartDate   string  `json:"startDate"`
+	EndDate     string  `json:"endDate"`
+	BasicPay    float64 `json:"basicPay"`
+	DepositRate float64 `json:"depositRate"`
+	Deposit     float64 `json:"deposit"`
+}
+
+// MilitaryDepositResult contains the deposit balance and break-even view
+type MilitaryDepositResult struct {
+	MilitaryYears           float64                       `json:"militaryYears"`
+	CreditedYears           float64                       `json:"creditedYears"`
+	PeriodDeposits          []MilitaryDepositPeriodResult `json:"periodDeposits"`
+	InitialDeposit          float64                       `json:"initialDeposit"`
+	InterestFreeUntil       string                        `json:"interestFreeUntil"`
+	InterestAccrued         float64                       `json:"interestAccrued"`
+	BalanceDue              float64                       `json:"balanceDue"`
+	AnnualAnnuityIncrease   float64                       `json:"annualAnnuityIncrease"`
+	BreakEvenYears          float64                       `json:"breakEvenYears"`
+	BreakEvenAge            float64                       `json:"breakEvenAge"`
+	LifetimeAnnuityIncrease float64                       `json:"lifetimeAnnuityIncrease"`
+	Notes                   string                        `json:"notes"`
 }
 
 // PensionResult is a minimal struct for frontend display
@@ -48,12 +191,74 @@ type PensionInput struct {
 type PensionResult struct {
 	AnnualPension  float64 `json:"annualPension"`
 	MonthlyPension float64 `json:"monthlyPension"`
+	RetirementType string  `json:"retirementType"`
+	SRSEligible    bool    `json:"srsEligible"`
+	FERSServiceYears float64 `json:"fersServiceYears"` // FERS service used for the SRS
+	ServiceYears   float64 `json:"serviceYears"` // Creditable service in the computation, before sick leave (all components)
+	SurvivorAnnuity float64 `json:"survivorAnnuity"`
+	PopUpAnnuity   float64 `json:"popUpAnnuity"` // Annuity restored if the survivor beneficiary dies first or the marriage ends (0 without a reduction)
+	Components     []PensionComponentResult `json:"components"`
+	YearlyAnnuity  []float64 `json:"yearlyAnnuity"` // Annuity by year since retirement, when it varies (e.g. disability)
+	High3          *High3Result `json:"high3,omitempty"` // Present when High-3 was computed from salary history
+	DepositItems   []DepositItemResult `json:"depositItems"`
+	DepositReduction float64 `json:"depositReduction"` // CSRS: permanent reduction for unpaid deposits/redeposits
+	AnnualPensionAt62 float64 `json:"annualPensionAt62"` // CSRS: annuity from 62 once military service without a deposit is removed (0 = no change)
+	MonthlyTaxFree float64 `json:"monthlyTaxFree"` // Tax-free part of each monthly payment (IRS Simplified Method)
+	FormerSpouseAward float64 `json:"formerSpouseAward"` // Annual court-ordered award included in AnnualPension
+	YearlyFormerSpouseAward []float64 `json:"yearlyFormerSpouseAward"` // Award by year since retirement
+	FormerSpouseSurvivorAnnuity float64 `json:"formerSpouseSurvivorAnnuity"`
 	Notes          string  `json:"notes"`
 }
 
+// High3Input contains a salary history for the High-3 calculation
+type High3Input struct {
+	SalaryHistory         []SalaryRateInput `json:"salaryHistory"`
+	RetirementDate        string            `json:"retirementDate"` // "YYYY-MM-DD"
+	ProjectedRaisePercent float64           `json:"projectedRaisePercent"`
+}
+
+// High3Result contains the High-3 average and the window it came from
+type High3Result struct {
+	High3Salary    float64           `json:"high3Salary"`
+	WindowStart    string            `json:"windowStart"`
+	WindowEnd      string            `json:"windowEnd"`
+	WindowRates    []SalaryRateInput `json:"windowRates"`
+	ProjectedRates []SalaryRateInput `json:"projectedRates"`
+	Notes          string            `json:"notes"`
+}
+
+// PensionComponentResult is one part of a multi-part annuity (e.g. FERS transferee)
+type PensionComponentResult struct {
+	System            string    `json:"system"`
+	YearsOfService    float64   `json:"yearsOfService"`
+	GrossAnnuity      float64   `json:"grossAnnuity"`
+	AgeReduction      float64   `json:"ageReduction"`
+	SurvivorReduction float64   `json:"survivorReduction"`
+	AnnualAnnuity     float64   `json:"annualAnnuity"`
+	SurvivorAnnuity   float64   `json:"survivorAnnuity"`
+	COLARule          string    `json:"colaRule"`
+	ProjectedAmounts  []float64 `json:"projectedAmounts"`
+}
+
+// toPensionComponentResult converts a model pension component to the API format
+func toPensionComponentResult(c models.PensionComponent) PensionComponentResult {
+	return PensionComponentResult{
+		System:            c.System,
+		YearsOfService:    c.YearsOfService,
+		GrossAnnuity:      c.GrossAnnuity,
+		AgeReduction:      c.AgeReduction,
+		SurvivorReduction: c.SurvivorReduction,
+		AnnualAnnuity:     c.AnnualAnnuity,
+		SurvivorAnnuity:   c.SurvivorAnnuity,
+		COLARule:          c.COLARule,
+		ProjectedAmounts:  c.ProjectedAmounts,
+	}
+}
+
 // SocialSecurityInput contains data for social security calculations
 type SocialSecurityInput struct {
 	StartAge                int     `json:"startAge"`
+	StartAgeMonths          int     `json:"startAgeMonths"` // Additional months of claiming age (0-11)
 	EstimatedMonthlyBenefit float64 `json:"estimatedMonthlyBenefit"`
 	IsEligible              bool    `json:"isEligible"`
 	BirthYear               int     `json:"birthYear"`
@@ -61,9 +266,38 @@ type SocialSecurityInput struct {
 	CurrentAge              int     `json:"currentAge"`
 	EstimatedAnnualSalary   float64 `json:"estimatedAnnualSalary"`
 	YearsWorked             int     `json:"yearsWorked"`
+	EarningsHistory         []float64 `json:"earningsHistory"` // Covered earnings by year, oldest first
+	EarningsStartYear       int     `json:"earningsStartYear"`    // Year of the first earnings entry
+	FutureAnnualEarnings    float64 `json:"futureAnnualEarnings"` // Projected earnings until stopWorkingAge
+	StopWorkingAge          int     `json:"stopWorkingAge"`
+	WageGrowthRate          float64 `json:"wageGrowthRate"`
+	ColaRate                float64 `json:"colaRate"` // Assumed COLA for years without a published one
+	YearlyEarnedIncome      []float64 `json:"yearlyEarnedIncome"` // Wages by year from the claiming year, for the earnings test
 	UserProvidedEstimate62  float64 `json:"userProvidedEstimate62"`
 	UserProvidedEstimateFRA float64 `json:"userProvidedEstimateFRA"`
 	UserProvidedEstimate70  float64 `json:"userProvidedEstimate70"`
+	TrustFund               *TrustFundPolicyInput `json:"trustFund"` // Optional: benefit cut after trust fund depletion
+}
+
+// TrustFundPolicyInput describes a Social Security trust fund shortfall
+type TrustFundPolicyInput struct {
+	DepletionYear int             `json:"depletionYear"` // First year of reduced benefits
+	PayableShare  float64         `json:"payableShare"`  // Share of scheduled benefits payable (0 uses 77%)
+	Mode          string          `json:"mode"`          // "uniform" or "cohort"
+	CohortShares  map[int]float64 `json:"cohortShares"`  // Cohort mode: payable share by birth year
+}
+
+// toTrustFundPolicy converts an optional API trust fund policy to the model format
+func toTrustFundPolicy(input *TrustFundPolicyInput) models.TrustFundPolicy {
+	if input == nil {
+		return models.TrustFundPolicy{}
+	}
+	return models.TrustFundPolicy{
+		DepletionYear: input.DepletionYear,
+		PayableShare:  input.PayableShare,
+		Mode:          input.Mode,
+		CohortShares:  input.CohortShares,
+	}
 }
 
 // SocialSecurityResult contains the calculated Social Security benefit amounts
@@ -75,9 +309,19 @@ type SocialSecurityResult struct {
 	EstimatedAnnualAtFRA  float64 `json:"estimatedAnnualAtFRA"`
 	EstimatedAnnualAt70   float64 `json:"estimatedAnnualAt70"`
 	ClaimingAge           int     `json:"claimingAge"`
+	ClaimingAgeMonths     int     `json:"claimingAgeMonths"`
+	ClaimingFactor        float64 `json:"claimingFactor"` // Benefit as a share of the PIA
 	ClaimingMonthlyAmount float64 `json:"claimingMonthlyAmount"`
 	ClaimingAnnualAmount  float64 `json:"claimingAnnualAmount"`
 	FullRetirementAge     int     `json:"fullRetirementAge"`
+	FullRetirementAgeMonths int   `json:"fullRetirementAgeMonths"`
+	AIME                  float64 `json:"aime"` // Average indexed monthly earnings
+	PIA                   float64 `json:"pia"`  // Primary insurance amount at 62, before COLAs
+	EarningsTestWithheld  float64 `json:"earningsTestWithheld"` // Benefits withheld for earnings before FRA
+	MonthsWithheld        int     `json:"monthsWithheld"`
+	MonthlyAfterFRA       float64 `json:"monthlyAfterFRA"` // Monthly benefit from FRA after the recomputation
+	PayableMonthlyAmount  float64 `json:"payableMonthlyAmount"` // Claiming amount once a trust fund shortfall applies
+	PayableFromYear       int     `json:"payableFromYear"`      // First year of the payable amount (0 = paid in full)
 	Notes                 string  `json:"notes"`
 }
 
@@ -130,6 +374,8 @@ type TaxInput struct {
 	TSPWithdrawals        float64 `json:"tspWithdrawals"`
 	OtherIncome           float64 `json:"otherIncome"`
 	NonTaxableIncome      float64 `json:"nonTaxableIncome"`
+	PensionTaxFreeAmount  float64 `json:"pensionTaxFreeAmount"` // Tax-free recovery of contributions included in TotalIncome
+	FormerSpouseAward     float64 `json:"formerSpouseAward"`    // Court-ordered award included in TotalIncome (taxed to the former spouse)
 	ItemizedDeductions    float64 `json:"itemizedDeductions"`
 	FederalTaxCredits     float64 `json:"federalTaxCredits"`
 	StateTaxCredits       float64 `json:"stateTaxCredits"`
@@ -191,6 +437,8 @@ type OtherIncomeSource struct {
 	StartAge   int     `json:"startAge"`
 	EndAge     *int    `json:"endAge"`
 	ApplyCola  bool    `json:"applyCola"`
+	Type       string  `json:"type"`         // "" for flat income, "earned" for wages or self-employment, "reemployedAnnuitant" for federal reemployment salary
+	OffsetWaived bool  `json:"offsetWaived"` // Reemployed annuitant: salary offset waived by the agency
 }
 
 // OtherIncomeInput contains additional income sources
@@ -208,6 +456,10 @@ type RetirementScenarioInput struct {
 	OtherIncome    OtherIncomeInput   `json:"otherIncome"`
 	ProjectionStartAge int             `json:"projectionStartAge"`
 	ProjectionEndAge   int             `json:"projectionEndAge"`
+	SpouseDeathYear    int             `json:"spouseDeathYear"` // Assumed year the spouse or survivor beneficiary dies (0 = none)
+	DivorceYear        int             `json:"divorceYear"`     // Assumed year the marriage ends (0 = none)
+	SpouseSocialSecurity *SocialSecurityInput `json:"spouseSocialSecurity"` // Optional: spouse's record for spousal and survivor benefits
+	TrustFund          *TrustFundPolicyInput `json:"trustFund"` // Optional: trust fund shortfall for every Social Security stream
 }
 
 // YearlyProjectionData contains calculated values for a specific year in retirement
@@ -215,7 +467,18 @@ type YearlyProjectionData struct {
 	Age              int     `json:"age"`
 	Year             int     `json:"year"`
 	PensionIncome    float64 `json:"pensionIncome"`
+	SRS              float64 `json:"srs"`              // FERS Annuity Supplement, paid until 62 (not in PensionIncome)
+	TaxFreePension   float64 `json:"taxFreePension"`   // Recovery of contributions (IRS Simplified Method)
+	UnrecoveredCost  float64 `json:"unrecoveredCost"`  // Contributions still to be recovered at year end
+	FormerSpouseAward float64 `json:"formerSpouseAward"` // Court-ordered award paid out of the annuity (not in PensionIncome)
+	SurvivorPopUp    bool    `json:"survivorPopUp"`    // True once the unreduced annuity is restored
+	EarnedIncome     float64 `json:"earnedIncome"`     // Wages for the SRS and Social Security earnings tests
+	SupplementalAnnuity float64 `json:"supplementalAnnuity"` // Supplemental annuity from reemployment (in PensionIncome)
+	FilingStatus     string  `json:"filingStatus"`     // Filing status used for the year
 	SocialSecurity   float64 `json:"socialSecurity"`
+	SpouseSocialSecurity float64 `json:"spouseSocialSecurity"` // Spouse's benefits (in SocialSecurity)
+	SSWithheld       float64 `json:"ssWithheld"`       // Benefits withheld under the earnings test before FRA
+	SSTrustFundCut   float64 `json:"ssTrustFundCut"`   // Scheduled benefits not payable after trust fund depletion
 	TSPWithdrawal    float64 `json:"tspWithdrawal"`
 	OtherIncome      float64 `json:"otherIncome"`
 	TotalGrossIncome float64 `json:"totalGrossIncome"`
@@ -233,81 +496,853 @@ type RetirementProjectionResult struct {
 	TotalNetIncome   float64                `json:"totalNetIncome"`
 	TotalTaxes       float64                `json:"totalTaxes"`
 	MaxTSPBalance    float64                `json:"maxTSPBalance"`
+	ScheduledTotalGrossIncome float64       `json:"scheduledTotalGrossIncome"` // TotalGrossIncome with scheduled Social Security
+	ScheduledTotalNetIncome   float64       `json:"scheduledTotalNetIncome"`
+	TrustFundIncomeReduction  float64       `json:"trustFundIncomeReduction"` // Lifetime gross income lost to the trust fund shortfall
 	Notes            string                 `json:"notes"`
 }
 
+// toServicePeriods converts frontend service periods to the model format
+func toServicePeriods(periods []ServicePeriodInput) ([]models.ServicePeriod, error) {
+	var result []models.ServicePeriod
+	for i, p := range periods {
+		start, err := time.Parse("2006-01-02", p.StartDate)
+		if err != nil {
+			return nil, fmt.Errorf("service period %d: invalid start date %q", i+1, p.StartDate)
+		}
+		end, err := time.Parse("2006-01-02", p.EndDate)
+		if err != nil {
+			return nil, fmt.Errorf("service period %d: invalid end date %q", i+1, p.EndDate)
+		}
+		var refundDate time.Time
+		if p.RefundDate != "" {
+			if refundDate, err = time.Parse("2006-01-02", p.RefundDate); err != nil {
+				return nil, fmt.Errorf("service period %d: invalid refund date %q", i+1, p.RefundDate)
+			}
+		}
+		result = append(result, models.ServicePeriod{
+			StartDate:             start,
+			EndDate:               end,
+			ServiceType:           p.ServiceType,
+			ScheduledHoursPerWeek: p.ScheduledHoursPerWeek,
+			Refunded:              p.Refunded,
+			DepositPaid:           p.DepositPaid,
+			BasicPay:              p.BasicPay,
+			RefundAmount:          p.RefundAmount,
+			RefundDate:            refundDate,
+		})
+	}
+	return result, nil
+}
+
+// toSalaryHistory converts frontend salary rates to the model format
+func toSalaryHistory(rates []SalaryRateInput) ([]models.SalaryRate, error) {
+	var result []models.SalaryRate
+	for i, r := range rates {
+		effective, err := time.Parse("2006-01-02", r.EffectiveDate)
+		if err != nil {
+			return nil, fmt.Errorf("salary entry %d: invalid effective date %q", i+1, r.EffectiveDate)
+		}
+		result = append(result, models.SalaryRate{
+			EffectiveDate:   effective,
+			Grade:           r.Grade,
+			Step:            r.Step,
+			BasePay:         r.BasePay,
+			LocalityPercent: r.LocalityPercent,
+			AnnualRate:      r.AnnualRate,
+			Reason:          r.Reason,
+		})
+	}
+	return result, nil
+}
+
+// fromSalaryHistory converts model salary rates to the API format
+func fromSalaryHistory(rates []models.SalaryRate) []SalaryRateInput {
+	var result []SalaryRateInput
+	for _, r := range rates {
+		result = append(result, SalaryRateInput{
+			EffectiveDate:   r.EffectiveDate.Format("2006-01-02"),
+			Grade:           r.Grade,
+			Step:            r.Step,
+			BasePay:         r.BasePay,
+			LocalityPercent: r.LocalityPercent,
+			AnnualRate:      r.AnnualRate,
+			Reason:          r.Reason,
+		})
+	}
+	return result
+}
+
+// CalculateHigh3 computes the High-3 average salary from a dated pay history
+//export
+func (a *App) CalculateHigh3(input High3Input) High3Result {
+	history, err := toSalaryHistory(input.SalaryHistory)
+	if err != nil {
+		return High3Result{Notes: err.Error()}
+	}
+	retirementDate, err := time.Parse("2006-01-02", input.RetirementDate)
+	if err != nil {
+		return High3Result{Notes: fmt.Sprintf("invalid retirement date %q", input.RetirementDate)}
+	}
+	result := calculation.CalculateHigh3(models.High3CalculationInput{
+		SalaryHistory:         history,
+		RetirementDate:        retirementDate,
+		ProjectedRaisePercent: input.ProjectedRaisePercent,
+	})
+	high3 := High3Result{
+		High3Salary:    result.High3Salary,
+		WindowRates:    fromSalaryHistory(result.WindowRates),
+		ProjectedRates: fromSalaryHistory(result.ProjectedRates),
+		Notes:          result.Notes,
+	}
+	if result.High3Salary > 0 {
+		high3.WindowStart = result.WindowStart.Format("2006-01-02")
+		high3.WindowEnd = result.WindowEnd.Format("2006-01-02")
+	}
+	return high3
+}
+
+// toMilitaryPeriods converts frontend military periods to the model format
+func toMilitaryPeriods(periods []MilitaryPeriodInput) ([]models.MilitaryPayPeriod, error) {
+	var result []models.MilitaryPayPeriod
+	for i, p := range periods {
+		start, err := time.Parse("2006-01-02", p.StartDate)
+		if err != nil {
+			return nil, fmt.Errorf("military period %d: invalid start date %q", i+1, p.StartDate)
+		}
+		end, err := time.Parse("2006-01-02", p.EndDate)
+		if err != nil {
+			return nil, fmt.Errorf("military period %d: invalid end date %q", i+1, p.EndDate)
+		}
+		result = append(result, models.MilitaryPayPeriod{StartDate: start, EndDate: end, BasicPay: p.BasicPay})
+	}
+	return result, nil
+}
+
+// CalculateMilitaryDeposit computes the military service deposit, interest and break-even point
+//export
+func (a *App) CalculateMilitaryDeposit(input MilitaryDepositInput) MilitaryDepositResult {
+	periods, err := toMilitaryPeriods(input.Periods)
+	if err != nil {
+		return MilitaryDepositResult{Notes: err.Error()}
+	}
+	var hireDate, payoffDate time.Time
+	if input.CivilianHireDate != "" {
+		if hireDate, err = time.Parse("2006-01-02", input.CivilianHireDate); err != nil {
+			return MilitaryDepositResult{Notes: fmt.Sprintf("invalid civilian hire date %q", input.CivilianHireDate)}
+		}
+	}
+	if input.PayoffDate != "" {
+		if payoffDate, err = time.Parse("2006-01-02", input.PayoffDate); err != nil {
+			return MilitaryDepositResult{Notes: fmt.Sprintf("invalid payoff date %q", input.PayoffDate)}
+		}
+	}
+	system := input.System
+	if system == "CSRS Offset" {
+		system = "CSRSOffset"
+	}
+	result := calculation.CalculateMilitaryDeposit(models.MilitaryDepositCalculationInput{
+		RetirementSystem:    system,
+		Periods:             periods,
+		CivilianHireDate:    hireDate,
+		PayoffDate:          payoffDate,
+		AssumedInterestRate: input.AssumedInterestRate,
+		DepositPaid:         input.DepositPaid,
+		High3Salary:         input.High3Salary,
+		AgeAtRetirement:     input.AgeAtRetirement,
+		LifeExpectancy:      input.LifeExpectancy,
+	})
+	var deposits []MilitaryDepositPeriodResult
+	for _, d := range result.PeriodDeposits {
+		deposits = append(deposits, MilitaryDepositPeriodResult{
+			StartDate:   d.StartDate.Format("2006-01-02"),
+			EndDate:     d.EndDate.Format("2006-01-02"),
+			BasicPay:    d.BasicPay,
+			DepositRate: d.DepositRate,
+			Deposit:     d.Deposit,
+		})
+	}
+	interestFreeUntil := ""
+	if !result.InterestFreeUntil.IsZero() {
+		interestFreeUntil = result.InterestFreeUntil.Format("2006-01-02")
+	}
+	return MilitaryDepositResult{
+		MilitaryYears:           result.MilitaryYears,
+		CreditedYears:           result.CreditedYears,
+		PeriodDeposits:          deposits,
+		InitialDeposit:          result.InitialDeposit,
+		InterestFreeUntil:       interestFreeUntil,
+		InterestAccrued:         result.InterestAccrued,
+		BalanceDue:              result.BalanceDue,
+		AnnualAnnuityIncrease:   result.AnnualAnnuityIncrease,
+		BreakEvenYears:          result.BreakEvenYears,
+		BreakEvenAge:            result.BreakEvenAge,
+		LifetimeAnnuityIncrease: result.LifetimeAnnuityIncrease,
+		Notes:                   result.Notes,
+	}
+}
+
+// toFERSInput maps the frontend pension input to the FERS calculation model
+func toFERSInput(input PensionInput, serviceHistory []models.ServicePeriod) models.FERSCalculationInput {
+	// The retirement date, when given, gives a month-precise age for the MRA checks
+	retirementYear, retirementMonth := 0, 0
+	if retirementDate, err := time.Parse("2006-01-02", input.RetirementDate); err == nil {
+		retirementYear, retirementMonth = retirementDate.Year(), int(retirementDate.Month())
+	}
+	return models.FERSCalculationInput{
+		High3Salary:             input.High3Salary,
+		YearsOfService:          input.YearsOfService,
+		AgeAtRetirement:         input.AgeAtRetirement,
+		UnusedSickLeaveMonths:   input.UnusedSickLeaveMonths,
+		UnusedSickLeaveHours:    input.UnusedSickLeaveHours,
+		SickLeaveSchedule:       input.SickLeaveSchedule,
+		SickLeaveHoursPerWeek:   input.SickLeaveHoursPerWeek,
+		SurvivorBenefitElection: toSurvivorElection(input.SurvivorBenefitOption),
+		IsPartTime:              input.IsPartTime,
+		PartTimeProrationFactor: input.PartTimeProrationFactor,
+		ServiceHistory:          serviceHistory,
+		BirthYear:               input.BirthYear,
+		BirthMonth:              input.BirthMonth,
+		RetirementYear:          retirementYear,
+		RetirementMonth:         retirementMonth,
+		RetirementOption:        input.RetirementOption,
+		AnnuityStartAge:         input.AnnuityStartAge,
+		EmployeeContributions:   input.EmployeeContributions,
+		BeneficiaryAge:          input.BeneficiaryAge,
+	}
+}
+
+// toCSRSInput maps the frontend pension input to the CSRS calculation model
+func toCSRSInput(input PensionInput, serviceHistory []models.ServicePeriod) models.CSRSCalculationInput {
+	return models.CSRSCalculationInput{
+		High3Salary:             input.High3Salary,
+		YearsOfService:          input.YearsOfService,
+		UnusedSickLeaveMonths:   input.UnusedSickLeaveMonths,
+		UnusedSickLeaveHours:    input.UnusedSickLeaveHours,
+		SickLeaveSchedule:       input.SickLeaveSchedule,
+		SickLeaveHoursPerWeek:   input.SickLeaveHoursPerWeek,
+		IsCSRSOffset:            input.System == "CSRS Offset",
+		AgeAtRetirement:         input.AgeAtRetirement,
+		AgeAtRetirementMonths:   input.AgeAtRetirementMonths,
+		RetirementOption:        input.RetirementOption,
+		SurvivorBenefitElection: toSurvivorElection(input.SurvivorBenefitOption),
+		SurvivorBaseAmount:      input.SurvivorBaseAmount,
+		EmployeeContributions:   input.EmployeeContributions,
+		BeneficiaryAge:          input.BeneficiaryAge,
+		IsPartTime:              input.IsPartTime,
+		PartTimeProrationFactor: input.PartTimeProrationFactor,
+		ServiceHistory:          serviceHistory,
+		EligibleForSocialSecurity: input.EligibleForSocialSecurity,
+	}
+}
+
+// toSurvivorElection maps the frontend survivor benefit option to the model format
+func toSurvivorElection(option string) string {
+	switch option {
+	case "full":
+		return "max"
+	case "partial":
+		return "partial"
+	case "insurableInterest":
+		return "insurable interest"
+	default:
+		return "none"
+	}
+}
+
+// popUpAnnuity returns the annuity restored when the survivor beneficiary dies first or the marriage ends
+func popUpAnnuity(annualPension, survivorReduction float64) float64 {
+	if survivorReduction <= 0 {
+		return 0
+	}
+	return annualPension + survivorReduction
+}
+
+// creditPaidMilitary adds paid-up military service to the service history or the years of service.
+// It returns false with the reason in the notes when the military service cannot be credited.
+func (a *App) creditPaidMilitary(input PensionInput) (PensionInput, string, bool) {
+	militaryYears := float64(input.MilitaryService)
+	if len(input.MilitaryPeriods) > 0 {
+		deposit := a.CalculateMilitaryDeposit(MilitaryDepositInput{System: input.System, Periods: input.MilitaryPeriods, DepositPaid: true})
+		if deposit.MilitaryYears == 0 {
+			return input, deposit.Notes, false
+		}
+		militaryYears = deposit.MilitaryYears
+	}
+	switch {
+	case len(input.ServicePeriods) > 0 && len(input.MilitaryPeriods) == 0:
+		return input, "Military service must be entered as dated periods when a service history is used.", false
+	case len(input.ServicePeriods) > 0:
+		for _, p := range input.MilitaryPeriods {
+			input.ServicePeriods = append(input.ServicePeriods, ServicePeriodInput{StartDate: p.StartDate, EndDate: p.EndDate, ServiceType: "military", DepositPaid: true})
+		}
+	case input.System == "FERS Transferee":
+		input.FERSYearsOfService += militaryYears
+	default:
+		input.YearsOfService += militaryYears
+	}
+	input.MilitaryDepositPaid = false
+	input.MilitaryService = 0
+	input.MilitaryPeriods = nil
+	return input, fmt.Sprintf("Military deposit paid: %.2f years of military service credited.\n", militaryYears), true
+}
+
 // CalculatePension computes a FERS or CSRS pension based on user input
 //export
 func (a *App) CalculatePension(input PensionInput) PensionResult {
-	if input.System == "FERS" {
-		// Check if qualifies for 1.1% multiplier (age 62+ with 20+ years)
-		isAge62With20Years := input.AgeAtRetirement >= 62 && input.YearsOfService >= 20
-		
-		// Map the survivor benefit option to the model format
-		var survivorBenefitOption string
-		switch input.SurvivorBenefitOption {
-		case "full":
-			survivorBenefitOption = "max"
-		case "partial":
-			survivorBenefitOption = "partial"
-		case "none":
-			survivorBenefitOption = "none"
-		default:
-			survivorBenefitOption = "none"
+	// A salary history replaces the hand-entered High-3
+	if len(input.SalaryHistory) > 0 {
+		high3 := a.CalculateHigh3(High3Input{
+			SalaryHistory:         input.SalaryHistory,
+			RetirementDate:        input.RetirementDate,
+			ProjectedRaisePercent: input.ProjectedRaisePercent,
+		})
+		if high3.High3Salary <= 0 {
+			return PensionResult{Notes: high3.Notes}
 		}
-		
-		fersInput := models.FERSCalculationInput{
-			High3Salary:             input.High3Salary,
-			YearsOfService:          input.YearsOfService,
-			AgeAtRetirement:         input.AgeAtRetirement,
-			UnusedSickLeaveMonths:   input.UnusedSickLeaveMonths,
-			IsAge62With20Years:      isAge62With20Years,
-			SurvivorBenefitElection: survivorBenefitOption,
-			IsPartTime:              input.IsPartTime,
-			PartTimeProrationFactor: input.PartTimeProrationFactor,
+		input.High3Salary = high3.High3Salary
+		input.SalaryHistory = nil
+		result := a.CalculatePension(input)
+		result.High3 = &high3
+		result.Notes = high3.Notes + result.Notes
+		return result
+	}
+
+	// Paid-up military service joins creditable service
+	if input.MilitaryDepositPaid && (input.MilitaryService > 0 || len(input.MilitaryPeriods) > 0) {
+		credited, notes, ok := a.creditPaidMilitary(input)
+		if !ok {
+			return PensionResult{Notes: notes}
+		}
+		result := a.CalculatePension(credited)
+		result.Notes = notes + result.Notes
+		return result
+	}
+
+	// A court order splits the unreduced annuity and shares the survivor cap with the former spouse
+	if input.CourtOrder != nil {
+		if input.System != "FERS" && input.System != "CSRS" && input.System != "CSRS Offset" {
+			return PensionResult{Notes: "Court-ordered awards are supported for FERS and CSRS annuities only."}
+		}
+		order := *input.CourtOrder
+		election := toSurvivorElection(input.SurvivorBenefitOption)
+		contributions := input.EmployeeContributions
+		input.CourtOrder = nil
+		input.SurvivorBenefitOption = "none"
+		input.EmployeeContributions = 0
+		result := a.CalculatePension(input)
+		if result.AnnualPension <= 0 {
+			return result
+		}
+		pensionType := "CSRS"
+		if input.System == "FERS" {
+			pensionType = "FERS"
+		}
+		coap := calculation.CalculateCOAP(models.COAPCalculationInput{
+			PensionType:       pensionType,
+			GrossAnnuity:      result.AnnualPension,
+			TotalServiceYears: result.ServiceYears,
+			Award: models.CourtOrderAward{
+				AwardType:           order.AwardType,
+				FixedMonthlyAmount:  order.FixedMonthlyAmount,
+				Percentage:          order.Percentage,
+				MaritalServiceYears: order.MaritalServiceYears,
+				Basis:               order.Basis,
+				FixedAmountGetsCOLA: order.FixedAmountGetsCOLA,
+			},
+			FormerSpouseSurvivorBase: order.FormerSpouseSurvivorBase,
+			CurrentSpouseElection:    election,
+			CurrentSpouseBase:        input.SurvivorBaseAmount,
+			HealthPremiums:           order.HealthPremiums,
+			LifeInsurancePremiums:    order.LifeInsurancePremiums,
+			COLARate:                 input.COLARate,
+			ProjectionYears:          input.ProjectionYears,
+		})
+		// The award is a share of the annuity, so the annuity from 62 keeps the same proportion
+		if result.AnnualPensionAt62 > 0 {
+			result.AnnualPensionAt62 *= coap.AnnuityAfterSurvivor / result.AnnualPension
 		}
+		result.AnnualPension = coap.AnnuityAfterSurvivor
+		result.MonthlyPension = coap.AnnuityAfterSurvivor / 12.0
+		result.SurvivorAnnuity = coap.CurrentSpouseSurvivorAnnuity
+		result.PopUpAnnuity = 0
+		if coap.CurrentSpouseSurvivorCost > 0 {
+			result.PopUpAnnuity = coap.AnnuityAfterSurvivor + coap.CurrentSpouseSurvivorCost
+		}
+		result.FormerSpouseSurvivorAnnuity = coap.FormerSpouseSurvivorAnnuity
+		// The exclusion uses the joint-life divisor when any survivor annuity is elected
+		beneficiaryAge := 0
+		if election != "none" || order.FormerSpouseSurvivorBase > 0 {
+			beneficiaryAge = input.BeneficiaryAge
+		}
+		if contributions > 0 {
+			taxFree := calculation.CalculateSimplifiedMethod(models.SimplifiedMethodInput{
+				EmployeeContributions: contributions,
+				MonthlyAnnuity:        result.MonthlyPension,
+				AgeAtAnnuityStart:     max(input.AgeAtRetirement, input.AnnuityStartAge),
+				BeneficiaryAge:        beneficiaryAge,
+			})
+			result.MonthlyTaxFree = taxFree.MonthlyTaxFree
+			result.Notes += taxFree.Notes
+		}
+		result.FormerSpouseAward = coap.FormerSpouseAward
+		result.YearlyFormerSpouseAward = coap.YearlyFormerSpouseAward
+		result.Notes += coap.Notes
+		return result
+	}
+
+	serviceHistory, err := toServicePeriods(input.ServicePeriods)
+	if err != nil {
+		return PensionResult{Notes: err.Error()}
+	}
+
+	// Map the survivor benefit option to the model format
+	survivorBenefitOption := toSurvivorElection(input.SurvivorBenefitOption)
+
+	if input.System == "FERS" {
+		fersInput := toFERSInput(input, serviceHistory)
 		
+		// Eligibility classification decides the age reduction and the 1.1% multiplier
 		fersResult := calculation.CalculateFERSPension(fersInput)
+		
+		// Disability retirees are paid a year-by-year disability annuity instead
+		if input.RetirementOption == "Disability" && fersResult.RetirementType == "Disability" {
+			disabilityResult := calculation.CalculateFERSDisability(models.FERSDisabilityCalculationInput{
+				High3Salary:             input.High3Salary,
+				YearsOfService:          fersResult.ComputationServiceYears,
+				AgeAtDisability:         input.AgeAtRetirement,
+				SSDisabilityBenefit:     input.SSDisabilityBenefit,
+				COLARate:                input.COLARate,
+				YearsToProject:          input.ProjectionYears,
+				SurvivorBenefitElection: survivorBenefitOption,
+				BeneficiaryAge:          input.BeneficiaryAge,
+				EmployeeContributions:   input.EmployeeContributions,
+			})
+			var yearlyAnnuity []float64
+			for _, year := range disabilityResult.YearlyAnnuity {
+				yearlyAnnuity = append(yearlyAnnuity, year.Annuity)
+			}
+			return PensionResult{
+				AnnualPension:  disabilityResult.FirstYearAnnuity,
+				MonthlyPension: disabilityResult.FirstYearAnnuity / 12.0,
+				RetirementType: fersResult.RetirementType,
+				ServiceYears:   fersResult.ComputationServiceYears,
+				PopUpAnnuity:   popUpAnnuity(disabilityResult.FirstYearAnnuity, disabilityResult.SurvivorBenefitReduction),
+				YearlyAnnuity:  yearlyAnnuity,
+				MonthlyTaxFree: disabilityResult.MonthlyTaxFreeAmount,
+				Notes:          fersResult.Notes + disabilityResult.Notes,
+			}
+		}
 		return PensionResult{
 			AnnualPension:  fersResult.AnnualPension,
 			MonthlyPension: fersResult.MonthlyPension,
+			RetirementType: fersResult.RetirementType,
+			SRSEligible:    fersResult.SRSPayable,
+			FERSServiceYears: fersResult.ComputationServiceYears,
+			ServiceYears:   fersResult.ComputationServiceYears,
+			SurvivorAnnuity: fersResult.SurvivorAnnuity,
+			PopUpAnnuity:   popUpAnnuity(fersResult.AnnualPension, fersResult.SurvivorBenefitReduction),
+			DepositItems:   toDepositItemResults(fersResult.DepositItems),
+			MonthlyTaxFree: fersResult.MonthlyTaxFreeAmount,
 			Notes:          fersResult.Notes,
 		}
 	} else if input.System == "CSRS" || input.System == "CSRS Offset" {
-		// Map the survivor benefit option to the model format
-		var survivorBenefitOption string
-		switch input.SurvivorBenefitOption {
-		case "full":
-			survivorBenefitOption = "max"
-		case "partial":
-			survivorBenefitOption = "partial"
-		case "none":
-			survivorBenefitOption = "none"
-		default:
-			survivorBenefitOption = "none"
-		}
+		csrsInput := toCSRSInput(input, serviceHistory)
 		
-		csrsInput := models.CSRSCalculationInput{
+		csrsResult := calculation.CalculateCSRS(csrsInput)
+		annualPensionAt62 := 0.0
+		if csrsResult.AnnualPensionAt62 != csrsResult.AnnualPension {
+			annualPensionAt62 = csrsResult.AnnualPensionAt62
+		}
+		return PensionResult{
+			AnnualPension:  csrsResult.AnnualPension,
+			MonthlyPension: csrsResult.MonthlyPension,
+			ServiceYears:   csrsResult.ComputationServiceYears,
+			SurvivorAnnuity: csrsResult.SurvivorAnnuity,
+			PopUpAnnuity:   popUpAnnuity(csrsResult.AnnualPension, csrsResult.SurvivorBenefitReduction),
+			DepositItems:   toDepositItemResults(csrsResult.DepositItems),
+			DepositReduction: csrsResult.DepositReduction,
+			AnnualPensionAt62: annualPensionAt62,
+			MonthlyTaxFree: csrsResult.MonthlyTaxFreeAmount,
+			Notes:          csrsResult.Notes,
+		}
+	} else if input.System == "FERS Transferee" {
+		var transferDate time.Time
+		if input.TransferDate != "" {
+			if transferDate, err = time.Parse("2006-01-02", input.TransferDate); err != nil {
+				return PensionResult{Notes: fmt.Sprintf("invalid transfer date %q", input.TransferDate)}
+			}
+		}
+		transfereeInput := models.FERSTransfereeCalculationInput{
 			High3Salary:             input.High3Salary,
-			YearsOfService:          input.YearsOfService,
+			CSRSYearsOfService:      input.CSRSYearsOfService,
+			FERSYearsOfService:      input.FERSYearsOfService,
 			UnusedSickLeaveMonths:   input.UnusedSickLeaveMonths,
-			IsCSRSOffset:            input.System == "CSRS Offset",
+			UnusedSickLeaveHours:    input.UnusedSickLeaveHours,
+			SickLeaveSchedule:       input.SickLeaveSchedule,
+			SickLeaveHoursPerWeek:   input.SickLeaveHoursPerWeek,
+			ServiceHistory:          serviceHistory,
+			TransferDate:            transferDate,
 			AgeAtRetirement:         input.AgeAtRetirement,
+			BirthYear:               input.BirthYear,
+			BirthMonth:              input.BirthMonth,
+			RetirementOption:        input.RetirementOption,
+			AnnuityStartAge:         input.AnnuityStartAge,
 			SurvivorBenefitElection: survivorBenefitOption,
-			IsPartTime:              input.IsPartTime,
-			PartTimeProrationFactor: input.PartTimeProrationFactor,
+			SurvivorBaseAmount:      input.SurvivorBaseAmount,
+			BeneficiaryAge:          input.BeneficiaryAge,
+			COLARate:                input.COLARate,
+			ProjectionYears:         input.ProjectionYears,
+			EmployeeContributions:   input.EmployeeContributions,
 		}
 		
-		csrsResult := calculation.CalculateCSRS(csrsInput)
+		transfereeResult := calculation.CalculateFERSTransferee(transfereeInput)
 		return PensionResult{
-			AnnualPension:  csrsResult.AnnualPension,
-			MonthlyPension: csrsResult.MonthlyPension,
-			Notes:          csrsResult.Notes,
+			AnnualPension:   transfereeResult.AnnualPension,
+			MonthlyPension:  transfereeResult.MonthlyPension,
+			RetirementType:  transfereeResult.RetirementType,
+			SRSEligible:     transfereeResult.SRSPayable,
+			FERSServiceYears: transfereeResult.FERSComponent.YearsOfService,
+			ServiceYears:    transfereeResult.ComputationServiceYears,
+			SurvivorAnnuity: transfereeResult.SurvivorAnnuity,
+			PopUpAnnuity:    popUpAnnuity(transfereeResult.AnnualPension, transfereeResult.CSRSComponent.SurvivorReduction+transfereeResult.FERSComponent.SurvivorReduction),
+			Components: []PensionComponentResult{
+				toPensionComponentResult(transfereeResult.CSRSComponent),
+				toPensionComponentResult(transfereeResult.FERSComponent),
+			},
+			MonthlyTaxFree: transfereeResult.MonthlyTaxFreeAmount,
+			Notes: transfereeResult.Notes,
 		}
 	}
 	return PensionResult{Notes: "Unknown retirement system."}
 }
 
+// DeferredPensionInput contains data for a deferred or postponed FERS annuity
+type DeferredPensionInput struct {
+	Pension         PensionInput `json:"pension"`
+	SeparationDate  string       `json:"separationDate"` // "YYYY-MM-DD"
+	CommencementAge int          `json:"commencementAge"`
+}
+
+// DeferredAnnuityOption is the annuity payable at one commencement age
+type DeferredAnnuityOption struct {
+	CommencementAge  int     `json:"commencementAge"`
+	ReductionPercent float64 `json:"reductionPercent"`
+	AnnualAnnuity    float64 `json:"annualAnnuity"`
+	RetirementType   string  `json:"retirementType"`
+}
+
+// DeferredPensionResult contains the deferred annuity and the table of commencement ages
+type DeferredPensionResult struct {
+	AgeAtSeparation          int                     `json:"ageAtSeparation"`
+	EarliestCommencementAge  int                     `json:"earliestCommencementAge"`
+	UnreducedCommencementAge int                     `json:"unreducedCommencementAge"`
+	CommencementAge          int                     `json:"commencementAge"`
+	AnnualPension            float64                 `json:"annualPension"`
+	MonthlyPension           float64                 `json:"monthlyPension"`
+	ReductionPercent         float64                 `json:"reductionPercent"`
+	RetirementType           string                  `json:"retirementType"`
+	SRSEligible              bool                    `json:"srsEligible"`
+	FEHBContinues            bool                    `json:"fehbContinues"`
+	CommencementTable        []DeferredAnnuityOption `json:"commencementTable"`
+	Notes                    string                  `json:"notes"`
+}
+
+// CalculateDeferredPension computes a deferred or postponed FERS annuity for a chosen commencement age
+//export
+func (a *App) CalculateDeferredPension(input DeferredPensionInput) DeferredPensionResult {
+	serviceHistory, err := toServicePeriods(input.Pension.ServicePeriods)
+	if err != nil {
+		return DeferredPensionResult{Notes: err.Error()}
+	}
+	separationDate, err := time.Parse("2006-01-02", input.SeparationDate)
+	if err != nil {
+		return DeferredPensionResult{Notes: fmt.Sprintf("Invalid separation date %q", input.SeparationDate)}
+	}
+	// The High-3 is frozen at separation
+	high3Notes := ""
+	if len(input.Pension.SalaryHistory) > 0 {
+		high3 := a.CalculateHigh3(High3Input{
+			SalaryHistory:  input.Pension.SalaryHistory,
+			RetirementDate: input.SeparationDate,
+		})
+		if high3.High3Salary <= 0 {
+			return DeferredPensionResult{Notes: high3.Notes}
+		}
+		input.Pension.High3Salary = high3.High3Salary
+		high3Notes = high3.Notes
+	}
+	
+	deferredResult := calculation.CalculateFERSDeferred(models.FERSDeferredCalculationInput{
+		FERSInput:       toFERSInput(input.Pension, serviceHistory),
+		SeparationDate:  separationDate,
+		CommencementAge: input.CommencementAge,
+	})
+	
+	var table []DeferredAnnuityOption
+	for _, option := range deferredResult.CommencementTable {
+		table = append(table, DeferredAnnuityOption{
+			CommencementAge:  option.CommencementAge,
+			ReductionPercent: option.ReductionPercent,
+			AnnualAnnuity:    option.AnnualAnnuity,
+			RetirementType:   option.RetirementType,
+		})
+	}
+	
+	return DeferredPensionResult{
+		AgeAtSeparation:          deferredResult.AgeAtSeparation,
+		EarliestCommencementAge:  deferredResult.EarliestCommencementAge,
+		UnreducedCommencementAge: deferredResult.UnreducedCommencementAge,
+		CommencementAge:          deferredResult.CommencementAge,
+		AnnualPension:            deferredResult.AnnualAnnuity,
+		MonthlyPension:           deferredResult.MonthlyAnnuity,
+		ReductionPercent:         deferredResult.ReductionPercent,
+		RetirementType:           deferredResult.RetirementType,
+		SRSEligible:              deferredResult.SRSPayable,
+		FEHBContinues:            deferredResult.FEHBContinues,
+		CommencementTable:        table,
+		Notes:                    high3Notes + deferredResult.Notes,
+	}
+}
+
+// AlternativeFormOfAnnuityInput contains data for the Alternative Form of Annuity election
+type AlternativeFormOfAnnuityInput struct {
+	Pension                      PensionInput `json:"pension"`
+	HasLifeThreateningAffliction bool         `json:"hasLifeThreateningAffliction"`
+}
+
+// AlternativeFormOfAnnuityResult contains the lump sum and reduced annuity
+type AlternativeFormOfAnnuityResult struct {
+	IsAvailable        bool    `json:"isAvailable"`
+	FullAnnuity        float64 `json:"fullAnnuity"`
+	LumpSum            float64 `json:"lumpSum"`
+	PresentValueFactor float64 `json:"presentValueFactor"`
+	AnnualReduction    float64 `json:"annualReduction"`
+	ReducedAnnuity     float64 `json:"reducedAnnuity"`
+	MonthlyAnnuity     float64 `json:"monthlyAnnuity"`
+	IsEstimate         bool    `json:"isEstimate"` // Reduction uses an approximate present value factor
+	Notes              string  `json:"notes"`
+}
+
+// CalculateAlternativeFormOfAnnuity computes the lump sum of contributions and the reduced annuity
+//export
+func (a *App) CalculateAlternativeFormOfAnnuity(input AlternativeFormOfAnnuityInput) AlternativeFormOfAnnuityResult {
+	serviceHistory, err := toServicePeriods(input.Pension.ServicePeriods)
+	if err != nil {
+		return AlternativeFormOfAnnuityResult{Notes: err.Error()}
+	}
+	pensionType := "FERS"
+	switch input.Pension.System {
+	case "FERS":
+	case "CSRS":
+		pensionType = "CSRS"
+	case "CSRS Offset":
+		pensionType = "CSRSOffset"
+	default:
+		return AlternativeFormOfAnnuityResult{Notes: "The Alternative Form of Annuity is available for FERS and CSRS annuities only."}
+	}
+	result := calculation.CalculateAlternativeFormOfAnnuity(models.AlternativeFormOfAnnuityInput{
+		PensionType:                  pensionType,
+		FERSInput:                    toFERSInput(input.Pension, serviceHistory),
+		CSRSInput:                    toCSRSInput(input.Pension, serviceHistory),
+		HasLifeThreateningAffliction: input.HasLifeThreateningAffliction,
+	})
+	return AlternativeFormOfAnnuityResult{
+		IsAvailable:        result.IsAvailable,
+		FullAnnuity:        result.FullAnnuity,
+		LumpSum:            result.LumpSum,
+		PresentValueFactor: result.PresentValueFactor,
+		AnnualReduction:    result.AnnualReduction,
+		ReducedAnnuity:     result.ReducedAnnuity,
+		MonthlyAnnuity:     result.MonthlyAnnuity,
+		IsEstimate:         result.IsEstimate,
+		Notes:              result.Notes,
+	}
+}
+
+// RefundComparisonInput contains data for comparing a refund with a deferred annuity
+type RefundComparisonInput struct {
+	Pension         PensionInput `json:"pension"`
+	SeparationDate  string       `json:"separationDate"` // "YYYY-MM-DD"
+	CommencementAge int          `json:"commencementAge"`
+	RefundInterest  float64      `json:"refundInterest"`
+	TaxRate         float64      `json:"taxRate"`
+	DiscountRate    float64      `json:"discountRate"`
+	COLARate        float64      `json:"colaRate"`
+	MortalityModel  string       `json:"mortalityModel"` // "fixed" or "gompertz"
+	LifeExpectancy  int          `json:"lifeExpectancy"`
+}
+
+// RefundComparisonResult contains the after-tax refund and the deferred annuity present value
+type RefundComparisonResult struct {
+	RefundGross          float64 `json:"refundGross"`
+	RefundTax            float64 `json:"refundTax"`
+	RefundNet            float64 `json:"refundNet"`
+	DeferredAnnuity      float64 `json:"deferredAnnuity"`
+	CommencementAge      int     `json:"commencementAge"`
+	DeferredPresentValue float64 `json:"deferredPresentValue"`
+	Advantage            float64 `json:"advantage"`
+	Recommendation       string  `json:"recommendation"`
+	Notes                string  `json:"notes"`
+}
+
+// CompareRefundWithDeferredAnnuity compares a refund of FERS contributions with the deferred annuity
+//export
+func (a *App) CompareRefundWithDeferredAnnuity(input RefundComparisonInput) RefundComparisonResult {
+	serviceHistory, err := toServicePeriods(input.Pension.ServicePeriods)
+	if err != nil {
+		return RefundComparisonResult{Notes: err.Error()}
+	}
+	separationDate, err := time.Parse("2006-01-02", input.SeparationDate)
+	if err != nil {
+		return RefundComparisonResult{Notes: fmt.Sprintf("Invalid separation date %q", input.SeparationDate)}
+	}
+	result := calculation.CompareRefundWithDeferredAnnuity(models.RefundComparisonInput{
+		FERSInput:       toFERSInput(input.Pension, serviceHistory),
+		SeparationDate:  separationDate,
+		CommencementAge: input.CommencementAge,
+		RefundInterest:  input.RefundInterest,
+		TaxRate:         input.TaxRate,
+		DiscountRate:    input.DiscountRate,
+		COLARate:        input.COLARate,
+		MortalityModel:  input.MortalityModel,
+		LifeExpectancy:  input.LifeExpectancy,
+	})
+	return RefundComparisonResult{
+		RefundGross:          result.RefundGross,
+		RefundTax:            result.RefundTax,
+		RefundNet:            result.RefundNet,
+		DeferredAnnuity:      result.DeferredAnnuity,
+		CommencementAge:      result.CommencementAge,
+		DeferredPresentValue: result.DeferredPresentValue,
+		Advantage:            result.Advantage,
+		Recommendation:       result.Recommendation,
+		Notes:                result.Notes,
+	}
+}
+
+// PhasedRetirementInput contains data for a phased retirement; Pension describes entry into phased retirement
+type PhasedRetirementInput struct {
+	Pension          PensionInput `json:"pension"`
+	FullTimeSalary   float64      `json:"fullTimeSalary"`
+	PhasedYears      float64      `json:"phasedYears"`
+	FinalHigh3Salary float64      `json:"finalHigh3Salary"` // High-3 at full retirement on full-time rates (0 = unchanged)
+}
+
+// PhasedRetirementResult contains the phased annuity, half-time schedule and composite annuity
+type PhasedRetirementResult struct {
+	IsEligible            bool    `json:"isEligible"`
+	ImmediateAnnuity      float64 `json:"immediateAnnuity"`
+	PhasedAnnuity         float64 `json:"phasedAnnuity"`
+	PhasedSalary          float64 `json:"phasedSalary"`
+	PhasedIncome          float64 `json:"phasedIncome"`
+	WorkHoursPerYear      float64 `json:"workHoursPerYear"`
+	MentoringHours        float64 `json:"mentoringHours"`
+	AgeAtFullRetirement   int     `json:"ageAtFullRetirement"`
+	PhasedAnnuityWithCOLA float64 `json:"phasedAnnuityWithCola"`
+	SecondAnnuity         float64 `json:"secondAnnuity"`
+	CompositeAnnuity      float64 `json:"compositeAnnuity"`
+	SurvivorReduction     float64 `json:"survivorReduction"`
+	SurvivorAnnuity       float64 `json:"survivorAnnuity"`
+	AnnualAnnuity         float64 `json:"annualAnnuity"`
+	MonthlyAnnuity        float64 `json:"monthlyAnnuity"`
+	Notes                 string  `json:"notes"`
+}
+
+// CalculatePhasedRetirement computes the phased annuity and the composite annuity at full retirement
+//export
+func (a *App) CalculatePhasedRetirement(input PhasedRetirementInput) PhasedRetirementResult {
+	// High-3 and service resolve as in CalculatePension: salary history, paid military deposit, service history
+	if len(input.Pension.SalaryHistory) > 0 {
+		high3 := a.CalculateHigh3(High3Input{
+			SalaryHistory:         input.Pension.SalaryHistory,
+			RetirementDate:        input.Pension.RetirementDate,
+			ProjectedRaisePercent: input.Pension.ProjectedRaisePercent,
+		})
+		if high3.High3Salary <= 0 {
+			return PhasedRetirementResult{Notes: high3.Notes}
+		}
+		input.Pension.High3Salary = high3.High3Salary
+		input.Pension.SalaryHistory = nil
+		result := a.CalculatePhasedRetirement(input)
+		result.Notes = high3.Notes + result.Notes
+		return result
+	}
+	if input.Pension.MilitaryDepositPaid && (input.Pension.MilitaryService > 0 || len(input.Pension.MilitaryPeriods) > 0) {
+		credited, notes, ok := a.creditPaidMilitary(input.Pension)
+		if !ok {
+			return PhasedRetirementResult{Notes: notes}
+		}
+		input.Pension = credited
+		result := a.CalculatePhasedRetirement(input)
+		result.Notes = notes + result.Notes
+		return result
+	}
+	serviceHistory, err := toServicePeriods(input.Pension.ServicePeriods)
+	if err != nil {
+		return PhasedRetirementResult{Notes: err.Error()}
+	}
+	
+	pensionType := input.Pension.System
+	if pensionType == "CSRS Offset" {
+		pensionType = "CSRS"
+	}
+	result := calculation.CalculatePhasedRetirement(models.PhasedRetirementInput{
+		PensionType:             pensionType,
+		High3Salary:             input.Pension.High3Salary,
+		YearsOfService:          input.Pension.YearsOfService,
+		AgeAtPhasedRetirement:   input.Pension.AgeAtRetirement,
+		BirthYear:               input.Pension.BirthYear,
+		FullTimeSalary:          input.FullTimeSalary,
+		PhasedYears:             input.PhasedYears,
+		FinalHigh3Salary:        input.FinalHigh3Salary,
+		UnusedSickLeaveMonths:   input.Pension.UnusedSickLeaveMonths,
+		UnusedSickLeaveHours:    input.Pension.UnusedSickLeaveHours,
+		SickLeaveSchedule:       input.Pension.SickLeaveSchedule,
+		SickLeaveHoursPerWeek:   input.Pension.SickLeaveHoursPerWeek,
+		IsPartTime:              input.Pension.IsPartTime,
+		PartTimeProrationFactor: input.Pension.PartTimeProrationFactor,
+		ServiceHistory:          serviceHistory,
+		COLARate:                input.Pension.COLARate,
+		SurvivorBenefitElection: toSurvivorElection(input.Pension.SurvivorBenefitOption),
+		SurvivorBaseAmount:      input.Pension.SurvivorBaseAmount,
+		BeneficiaryAge:          input.Pension.BeneficiaryAge,
+	})
+	return PhasedRetirementResult{
+		IsEligible:            result.IsEligible,
+		ImmediateAnnuity:      result.ImmediateAnnuity,
+		PhasedAnnuity:         result.PhasedAnnuity,
+		PhasedSalary:          result.PhasedSalary,
+		PhasedIncome:          result.PhasedIncome,
+		WorkHoursPerYear:      result.WorkHoursPerYear,
+		MentoringHours:        result.MentoringHours,
+		AgeAtFullRetirement:   result.AgeAtFullRetirement,
+		PhasedAnnuityWithCOLA: result.PhasedAnnuityWithCOLA,
+		SecondAnnuity:         result.SecondAnnuity,
+		CompositeAnnuity:      result.CompositeAnnuity,
+		SurvivorReduction:     result.SurvivorReduction,
+		SurvivorAnnuity:       result.SurvivorAnnuity,
+		AnnualAnnuity:         result.AnnualAnnuity,
+		MonthlyAnnuity:        result.AnnualAnnuity / 12.0,
+		Notes:                 result.Notes,
+	}
+}
+
+// otherIncomeAmount returns a source's annual income at the given age (0 outside its ages), with COLAs if it has them
+func otherIncomeAmount(source OtherIncomeSource, age int, inflationRate float64) float64 {
+	if age < source.StartAge || (source.EndAge != nil && age > *source.EndAge) {
+		return 0
+	}
+	amount := source.Amount
+	if source.Frequency == "monthly" {
+		amount *= 12
+	}
+	if source.ApplyCola && age > source.StartAge {
+		amount *= math.Pow(1+inflationRate, float64(age-source.StartAge))
+	}
+	return amount
+}
+
 // CalculateRetirementProjection generates a complete retirement income projection
 //export
 func (a *App) CalculateRetirementProjection(input RetirementScenarioInput) RetirementProjectionResult {
@@ -316,7 +1351,26 @@ func (a *App) CalculateRetirementProjection(input RetirementScenarioInput) Retir
 		Notes: "",
 	}
 	
-	// Calculate pension
+	// Calculate pension (birth date defaults to the Social Security inputs for the MRA lookup)
+	if input.Pension.BirthYear == 0 {
+		input.Pension.BirthYear = input.SocialSecurity.BirthYear
+		input.Pension.BirthMonth = input.SocialSecurity.BirthMonth
+	}
+	// Transferee components and disability annuities are projected year by year by the pension calculators
+	if input.Pension.ProjectionYears == 0 {
+		if input.COLA.ApplyCOLAToPension {
+			input.Pension.COLARate = input.COLA.AssumedInflationRate
+		}
+		projectionEnd := input.ProjectionEndAge
+		if projectionEnd == 0 {
+			projectionEnd = 95
+		}
+		input.Pension.ProjectionYears = projectionEnd - input.Pension.AgeAtRetirement + 1
+	}
+	// CSRS military service without a deposit is removed at 62 when eligible for Social Security
+	if input.SocialSecurity.IsEligible {
+		input.Pension.EligibleForSocialSecurity = true
+	}
 	pensionResult := a.CalculatePension(input.Pension)
 	
 	// Get birth year and month for age calculations
@@ -347,6 +1401,89 @@ func (a *App) CalculateRetirementProjection(input RetirementScenarioInput) Retir
 	// Current TSP balance at retirement start
 	currentTSPBalance := input.TSP.CurrentBalance
 	
+	// Contributions not yet recovered tax-free under the Simplified Method
+	unrecoveredCost := 0.0
+	if pensionResult.MonthlyTaxFree > 0 {
+		unrecoveredCost = input.Pension.EmployeeContributions
+	}
+	
+	// Reemployed annuitant salaries are offset by the annuity and may earn a supplemental annuity
+	reemployment := make(map[int]models.ReemployedAnnuitantResult)
+	for i, source := range input.OtherIncome.Sources {
+		if source.Type != "reemployedAnnuitant" {
+			continue
+		}
+		salary := source.Amount
+		if source.Frequency == "monthly" {
+			salary *= 12
+		}
+		lastAge := endAge
+		if source.EndAge != nil {
+			lastAge = *source.EndAge
+		}
+		annuityAtStart := pensionResult.AnnualPension
+		if input.COLA.ApplyCOLAToPension && source.StartAge > input.Pension.AgeAtRetirement {
+			annuityAtStart *= math.Pow(1+input.COLA.AssumedInflationRate, float64(source.StartAge-input.Pension.AgeAtRetirement))
+		}
+		reemployedInput := models.ReemployedAnnuitantInput{
+			PensionType:   "FERS",
+			AnnualSalary:  salary,
+			AnnualAnnuity: annuityAtStart,
+			StartAge:      source.StartAge,
+			EndAge:        lastAge,
+			OffsetWaived:  source.OffsetWaived,
+		}
+		if input.Pension.System == "CSRS" || input.Pension.System == "CSRS Offset" {
+			reemployedInput.PensionType = "CSRS"
+		}
+		if input.COLA.ApplyCOLAToPension {
+			reemployedInput.COLARate = input.COLA.AssumedInflationRate
+		}
+		if source.ApplyCola {
+			reemployedInput.SalaryGrowthRate = input.COLA.AssumedInflationRate
+		}
+		reemployment[i] = calculation.CalculateReemployedAnnuitant(reemployedInput)
+		result.Notes += reemployment[i].Notes
+	}
+	
+	// The unreduced annuity pops up the year after the spouse dies or the marriage ends
+	popUpYear := 0
+	if input.SpouseDeathYear > 0 {
+		popUpYear = input.SpouseDeathYear + 1
+	}
+	// Divorce ends only a spousal survivor annuity; an insurable interest annuity continues
+	spousalElection := input.Pension.SurvivorBenefitOption == "partial" || input.Pension.SurvivorBenefitOption == "full"
+	if input.DivorceYear > 0 && spousalElection && (popUpYear == 0 || input.DivorceYear+1 < popUpYear) {
+		popUpYear = input.DivorceYear + 1
+	}
+	if input.DivorceYear > 0 && input.Pension.SurvivorBenefitOption == "insurableInterest" {
+		result.Notes += "Divorce does not end an insurable interest election; the survivor reduction continues.\n"
+	}
+	
+	// A projection-wide trust fund policy takes precedence over the one on the Social Security input
+	trustFund := toTrustFundPolicy(input.TrustFund)
+	if trustFund.DepletionYear == 0 {
+		trustFund = toTrustFundPolicy(input.SocialSecurity.TrustFund)
+	}
+	
+	// With a spouse's record, benefits are paid to the household; the larger continues after a death
+	var household *models.HouseholdSocialSecurityInput
+	var householdResult models.HouseholdSocialSecurityResult
+	householdFirstClaimAge := 0
+	if input.SpouseSocialSecurity != nil {
+		hh := models.HouseholdSocialSecurityInput{
+			Worker: a.householdMember(input.SocialSecurity),
+			Spouse: a.householdMember(*input.SpouseSocialSecurity),
+		}
+		if input.SpouseDeathYear > 0 {
+			hh.Spouse.DeathAge = input.SpouseDeathYear - hh.Spouse.BirthYear
+		}
+		householdResult = calculation.CalculateHouseholdSocialSecurity(hh)
+		household = &hh
+		householdFirstClaimAge = min(householdResult.Worker.ClaimAgeMonths, householdResult.Spouse.ClaimAgeMonths+(hh.Spouse.BirthYear-hh.Worker.BirthYear)*12) / 12
+		result.Notes += householdResult.Notes
+	}
+
 	// Calculate more accurate starting year based on birth date
 	startYear := currentYear
 	
@@ -356,6 +1493,96 @@ func (a *App) CalculateRetirementProjection(input RetirementScenarioInput) Retir
 		userCurrentAge--
 	}
 	
+	// Reemployment and other wages by calendar year, for the SRS and Social Security earnings tests
+	earnedInYear := func(year int) float64 {
+		age := userCurrentAge + (year - startYear)
+		earned := 0.0
+		for _, reemployed := range reemployment {
+			for _, y := range reemployed.Years {
+				if y.Age == age {
+					earned += y.SalaryPaid
+				}
+			}
+		}
+		for _, source := range input.OtherIncome.Sources {
+			if source.Type == "earned" {
+				earned += otherIncomeAmount(source, age, input.COLA.AssumedInflationRate)
+			}
+		}
+		return earned
+	}
+
+	// The FERS supplement is paid as its own stream until 62, tested against each year's earnings
+	srsByYear := make(map[int]models.SRSYear)
+	if pensionResult.SRSEligible && input.Pension.AgeAtRetirement < 62 {
+		retirementYear := startYear + (input.Pension.AgeAtRetirement - userCurrentAge)
+		retirementMonth := birthMonth
+		if retirementDate, err := time.Parse("2006-01-02", input.Pension.RetirementDate); err == nil {
+			retirementYear, retirementMonth = retirementDate.Year(), int(retirementDate.Month())
+		}
+		var yearlyEarned []float64
+		for year := retirementYear; year <= birthYear+62; year++ {
+			yearlyEarned = append(yearlyEarned, earnedInYear(year))
+		}
+		fersYears := pensionResult.FERSServiceYears
+		if fersYears == 0 {
+			fersYears = input.Pension.YearsOfService
+		}
+		srsResult := calculation.CalculateSRS(models.SRSCalculationInput{
+			EstimatedSocialSecurityAt62: a.CalculateSocialSecurity(input.SocialSecurity).EstimatedAnnualAt62,
+			YearsOfFERSService:          fersYears,
+			RetirementAge:               input.Pension.AgeAtRetirement,
+			RetirementType:              pensionResult.RetirementType,
+			BirthYear:                   birthYear,
+			BirthMonth:                  birthMonth,
+			RetirementYear:              retirementYear,
+			RetirementMonth:             retirementMonth,
+			YearlyEarnedIncome:          yearlyEarned,
+			EarningsLimitGrowthRate:     input.COLA.AssumedInflationRate,
+			IsSpecialCategory:           input.Pension.IsSpecialCategory,
+		})
+		for _, y := range srsResult.YearlySRS {
+			srsByYear[y.Year] = y
+		}
+		result.Notes += srsResult.Notes
+	}
+	
+	// Social Security claimed before FRA is withheld for earnings over the limit, then recomputed at FRA
+	ssEarningsTest := make(map[int]models.SSEarningsTestYear)
+	ssRecomputedRatio := 1.0
+	ssFRAYears, ssFRAMonths := calculation.FullRetirementAge(birthYear)
+	ssFRAMonth := birthYear*12 + max(birthMonth, 1) - 1 + ssFRAYears*12 + ssFRAMonths
+	ssMonthlyBenefit := input.SocialSecurity.EstimatedMonthlyBenefit
+	ssCOLARate := 0.0
+	if input.COLA.ApplyColaToSocialSecurity {
+		ssCOLARate = input.COLA.AssumedInflationRate
+	}
+	if household != nil {
+		ssMonthlyBenefit = householdResult.Worker.OwnBenefit + householdResult.Worker.SpousalBenefit
+	}
+	if ssMonthlyBenefit > 0 && (input.SocialSecurity.IsEligible || household != nil) {
+		claimYear := (birthYear*12 + max(birthMonth, 1) - 1 + input.SocialSecurity.StartAge*12 + input.SocialSecurity.StartAgeMonths) / 12
+		var yearlyEarned []float64
+		for year := claimYear; year <= ssFRAMonth/12; year++ {
+			yearlyEarned = append(yearlyEarned, earnedInYear(year))
+		}
+		test := calculation.CalculateSSEarningsTest(models.SSEarningsTestInput{
+			BirthYear:               birthYear,
+			BirthMonth:              birthMonth,
+			ClaimAge:                input.SocialSecurity.StartAge,
+			ClaimAgeMonths:          input.SocialSecurity.StartAgeMonths,
+			MonthlyBenefit:          ssMonthlyBenefit,
+			COLARate:                ssCOLARate,
+			YearlyEarnedIncome:      yearlyEarned,
+			EarningsLimitGrowthRate: input.COLA.AssumedInflationRate,
+		})
+		for _, y := range test.Years {
+			ssEarningsTest[y.Year] = y
+		}
+		ssRecomputedRatio = test.MonthlyBenefitAfterFRA / ssMonthlyBenefit
+		result.Notes += test.Notes
+	}
+
 	// Calculate projections for each year
 	for age := startAge; age <= endAge; age++ {
 		// Calculate the correct year based on current age and projection age
@@ -369,24 +1596,118 @@ func (a *App) CalculateRetirementProjection(input RetirementScenarioInput) Retir
 		
 		// Calculate pension income (with COLA if applicable)
 		pensionIncome := pensionResult.AnnualPension
-		if input.COLA.ApplyCOLAToPension && age > input.Pension.AgeAtRetirement {
-			yearsSinceRetirement := age - input.Pension.AgeAtRetirement
+		yearsSinceRetirement := age - input.Pension.AgeAtRetirement
+		if len(pensionResult.YearlyAnnuity) > 0 {
+			// Year-by-year annuity already includes COLAs and any recomputation
+			pensionIncome = 0
+			if yearsSinceRetirement >= 0 && yearsSinceRetirement < len(pensionResult.YearlyAnnuity) {
+				pensionIncome = pensionResult.YearlyAnnuity[yearsSinceRetirement]
+			}
+		} else if input.COLA.ApplyCOLAToPension && age > input.Pension.AgeAtRetirement {
 			inflationFactor := math.Pow(1+input.COLA.AssumedInflationRate, float64(yearsSinceRetirement))
 			pensionIncome *= inflationFactor
+			
+			if len(pensionResult.Components) > 0 {
+				pensionIncome = 0
+				for _, component := range pensionResult.Components {
+					if yearsSinceRetirement < len(component.ProjectedAmounts) {
+						amount := component.ProjectedAmounts[yearsSinceRetirement]
+						// Each component is restored with the COLAs of its own system
+						if popUpYear > 0 && year >= popUpYear && component.AnnualAnnuity > 0 {
+							amount *= (component.AnnualAnnuity + component.SurvivorReduction) / component.AnnualAnnuity
+						}
+						pensionIncome += amount
+					}
+				}
+			}
+		}
+		// CSRS military service without a deposit drops out of the annuity at 62, COLAs kept
+		if pensionResult.AnnualPensionAt62 > 0 && pensionResult.AnnualPension > 0 && age >= 62 {
+			pensionIncome *= pensionResult.AnnualPensionAt62 / pensionResult.AnnualPension
+		}
+		if popUpYear > 0 && year >= popUpYear && pensionResult.PopUpAnnuity > 0 && pensionResult.AnnualPension > 0 && age >= input.Pension.AgeAtRetirement {
+			// The restored annuity carries the COLAs paid since retirement
+			if len(pensionResult.Components) == 0 || !input.COLA.ApplyCOLAToPension || age == input.Pension.AgeAtRetirement {
+				pensionIncome *= pensionResult.PopUpAnnuity / pensionResult.AnnualPension
+			}
+			yearData.SurvivorPopUp = true
+		}
+		// A former spouse award comes out of the annuity and is taxed to the former spouse
+		if yearsSinceRetirement >= 0 && pensionResult.FormerSpouseAward > 0 {
+			award := pensionResult.FormerSpouseAward
+			if yearsSinceRetirement < len(pensionResult.YearlyFormerSpouseAward) {
+				award = pensionResult.YearlyFormerSpouseAward[yearsSinceRetirement]
+			}
+			award = math.Min(award, pensionIncome)
+			yearData.FormerSpouseAward = award
+			pensionIncome -= award
+		}
+		for _, reemployed := range reemployment {
+			if reemployed.SupplementalEligible && age >= reemployed.SupplementalStartAge {
+				supplemental := reemployed.SupplementalAnnuity
+				if input.COLA.ApplyCOLAToPension {
+					supplemental *= math.Pow(1+input.COLA.AssumedInflationRate, float64(age-reemployed.SupplementalStartAge))
+				}
+				yearData.SupplementalAnnuity += supplemental
+				pensionIncome += supplemental
+			}
 		}
 		yearData.PensionIncome = pensionIncome
+		taxFreePension := math.Min(math.Min(pensionResult.MonthlyTaxFree*12, unrecoveredCost), pensionIncome)
+		if age < input.Pension.AgeAtRetirement || taxFreePension < 0 {
+			taxFreePension = 0
+		}
+		unrecoveredCost -= taxFreePension
+		yearData.TaxFreePension = taxFreePension
+		yearData.UnrecoveredCost = unrecoveredCost
 		
 		// Calculate Social Security income
-		ssIncome := 0.0
-		if input.SocialSecurity.IsEligible && age >= input.SocialSecurity.StartAge {
-			ssIncome = input.SocialSecurity.EstimatedMonthlyBenefit * 12
-			if input.COLA.ApplyColaToSocialSecurity && age > input.SocialSecurity.StartAge {
-				yearsSinceSsStart := age - input.SocialSecurity.StartAge
-				inflationFactor := math.Pow(1+input.COLA.AssumedInflationRate, float64(yearsSinceSsStart))
-				ssIncome *= inflationFactor
+		workerSSIncome := 0.0
+		spouseSSIncome := 0.0
+		ssColaStartAge := input.SocialSecurity.StartAge
+		if household != nil {
+			for month := 0; month < 12; month++ {
+				workerBenefit, spouseBenefit := calculation.HouseholdBenefitsAt(*household, householdResult, age*12+month)
+				workerSSIncome += workerBenefit
+				spouseSSIncome += spouseBenefit
+			}
+			ssColaStartAge = householdFirstClaimAge
+		} else if input.SocialSecurity.IsEligible && age >= input.SocialSecurity.StartAge {
+			workerSSIncome = input.SocialSecurity.EstimatedMonthlyBenefit * 12
+			if age == input.SocialSecurity.StartAge {
+				// Benefits start in the claiming month
+				workerSSIncome = input.SocialSecurity.EstimatedMonthlyBenefit * float64(12-input.SocialSecurity.StartAgeMonths)
+			}
+		}
+		if input.COLA.ApplyColaToSocialSecurity && age > ssColaStartAge {
+			inflationFactor := math.Pow(1+input.COLA.AssumedInflationRate, float64(age-ssColaStartAge))
+			workerSSIncome *= inflationFactor
+			spouseSSIncome *= inflationFactor
+		}
+		// Benefits with COLAs are withheld for earnings before FRA and recomputed at FRA
+		if testYear, ok := ssEarningsTest[year]; ok {
+			workerSSIncome -= math.Min(testYear.Withheld, workerSSIncome)
+			yearData.SSWithheld = testYear.Withheld
+		}
+		if ssRecomputedRatio != 1 && workerSSIncome > 0 && year >= ssFRAMonth/12 {
+			share := 1.0
+			if year == ssFRAMonth/12 {
+				share = float64(12-ssFRAMonth%12) / 12.0
 			}
+			workerSSIncome *= 1 + (ssRecomputedRatio-1)*share
 		}
+		// After trust fund depletion only the payable share of each spouse's scheduled benefits is paid
+		if trustFund.DepletionYear > 0 {
+			scheduled := workerSSIncome + spouseSSIncome
+			workerSSIncome *= calculation.TrustFundPayableShare(trustFund, year, birthYear)
+			if household != nil {
+				spouseSSIncome *= calculation.TrustFundPayableShare(trustFund, year, household.Spouse.BirthYear)
+			}
+			yearData.SSTrustFundCut = scheduled - workerSSIncome - spouseSSIncome
+		}
+		ssIncome := workerSSIncome + spouseSSIncome
 		yearData.SocialSecurity = ssIncome
+		yearData.SpouseSocialSecurity = spouseSSIncome
 		
 		// Calculate TSP withdrawals
 		tspWithdrawal := 0.0
@@ -412,33 +1733,46 @@ func (a *App) CalculateRetirementProjection(input RetirementScenarioInput) Retir
 		
 		// Calculate other income
 		otherIncome := 0.0
-		for _, source := range input.OtherIncome.Sources {
-			if age >= source.StartAge && (source.EndAge == nil || age <= *source.EndAge) {
-				amount := source.Amount
-				if source.Frequency == "monthly" {
-					amount *= 12
-				}
-				
-				if source.ApplyCola && age > source.StartAge {
-					yearsSinceStart := age - source.StartAge
-					inflationFactor := math.Pow(1+input.COLA.AssumedInflationRate, float64(yearsSinceStart))
-					amount *= inflationFactor
+		earnedIncome := 0.0
+		for i, source := range input.OtherIncome.Sources {
+			if reemployed, ok := reemployment[i]; ok {
+				for _, year := range reemployed.Years {
+					if year.Age == age {
+						earnedIncome += year.SalaryPaid
+						otherIncome += year.SalaryPaid
+					}
 				}
-				
-				otherIncome += amount
+				continue
+			}
+			amount := otherIncomeAmount(source, age, input.COLA.AssumedInflationRate)
+			if source.Type == "earned" {
+				earnedIncome += amount
 			}
+			otherIncome += amount
 		}
 		yearData.OtherIncome = otherIncome
+		yearData.EarnedIncome = earnedIncome
+		
+		// FERS supplement (no COLA, stops at 62)
+		srsIncome := srsByYear[year].Paid
+		yearData.SRS = srsIncome
 		
 		// Calculate total gross income
-		yearData.TotalGrossIncome = pensionIncome + ssIncome + tspWithdrawal + otherIncome
+		yearData.TotalGrossIncome = pensionIncome + srsIncome + ssIncome + tspWithdrawal + otherIncome
 		
 		// Simplified tax calculation
-		totalTaxableIncome := pensionIncome + ssIncome*0.85 + tspWithdrawal // Assume 85% of SS is taxable
+		totalTaxableIncome := pensionIncome - taxFreePension + srsIncome + ssIncome*0.85 + tspWithdrawal // Assume 85% of SS is taxable
+		
+		// A surviving spouse files jointly for the year of death; a divorced retiree files single for the year of the divorce
+		filingStatus := input.Tax.FilingStatus
+		if filingStatus == "married_joint" && ((input.SpouseDeathYear > 0 && year > input.SpouseDeathYear) || (input.DivorceYear > 0 && year >= input.DivorceYear)) {
+			filingStatus = "single"
+		}
+		yearData.FilingStatus = filingStatus
 		
 		// Very simplified federal tax calculation (would need more complex bracketing in real implementation)
 		var federalTaxRate float64
-		switch input.Tax.FilingStatus {
+		switch filingStatus {
 		case "married_joint":
 			if totalTaxableIncome < 20000 {
 				federalTaxRate = 0.10
@@ -508,35 +1842,146 @@ func (a *App) CalculateRetirementProjection(input RetirementScenarioInput) Retir
 	result.TotalNetIncome = cumulativeNetIncome
 	result.TotalTaxes = cumulativeTaxes
 	result.MaxTSPBalance = maxTSPBalance
+	if popUpYear > 0 && pensionResult.PopUpAnnuity > 0 {
+		result.Notes += fmt.Sprintf("Survivor reduction removed from %d: unreduced annuity of $%.2f (before COLAs) restored.\n", popUpYear, pensionResult.PopUpAnnuity)
+	}
+	
+	// Compare against the same scenario with scheduled Social Security benefits
+	result.ScheduledTotalGrossIncome = result.TotalGrossIncome
+	result.ScheduledTotalNetIncome = result.TotalNetIncome
+	if trustFund.DepletionYear > 0 {
+		scheduledInput := input
+		scheduledInput.TrustFund = nil
+		scheduledInput.SocialSecurity.TrustFund = nil
+		scheduled := a.CalculateRetirementProjection(scheduledInput)
+		result.ScheduledTotalGrossIncome = scheduled.TotalGrossIncome
+		result.ScheduledTotalNetIncome = scheduled.TotalNetIncome
+		result.TrustFundIncomeReduction = scheduled.TotalGrossIncome - result.TotalGrossIncome
+		result.Notes += fmt.Sprintf("Trust fund shortfall from %d: lifetime gross income $%.2f vs $%.2f with scheduled Social Security ($%.2f less; net $%.2f less).\n", trustFund.DepletionYear, result.TotalGrossIncome, scheduled.TotalGrossIncome, result.TrustFundIncomeReduction, scheduled.TotalNetIncome-result.TotalNetIncome)
+	}
 	
 	return result
 }
 
-// CalculateSocialSecurity computes projected Social Security benefits based on user input
+// SurvivorElectionOption is the projected outcome of one survivor election
+type SurvivorElectionOption struct {
+	Option              string  `json:"option"` // "none", "partial", "full", "insurableInterest"
+	AnnualPension       float64 `json:"annualPension"`
+	SurvivorReduction   float64 `json:"survivorReduction"`
+	SurvivorAnnuity     float64 `json:"survivorAnnuity"`
+	LifetimePension     float64 `json:"lifetimePension"`     // Retiree's pension over the projection, with any pop-up
+	LifetimeNetIncome   float64 `json:"lifetimeNetIncome"`
+	LifetimeCost        float64 `json:"lifetimeCost"`        // Pension given up versus no election, with any pop-up
+	LifetimeCostNoPopUp float64 `json:"lifetimeCostNoPopUp"` // Pension given up if the reduction were never removed
+	PopUpYear           int     `json:"popUpYear"`           // First year the unreduced annuity is paid (0 = never)
+}
+
+// SurvivorElectionComparisonResult compares the survivor elections for one scenario
+type SurvivorElectionComparisonResult struct {
+	Options []SurvivorElectionOption `json:"options"`
+	Notes   string                   `json:"notes"`
+}
+
+// CompareSurvivorElections projects the scenario under each survivor election, with and without the pop-up
 //export
-func (a *App) CalculateSocialSecurity(input SocialSecurityInput) SocialSecurityResult {
-	// Convert from API input to calculation model input
-	ssInput := models.SocialSecurityCalculationInput{
+func (a *App) CompareSurvivorElections(input RetirementScenarioInput) SurvivorElectionComparisonResult {
+	result := SurvivorElectionComparisonResult{}
+	if input.Pension.BirthYear == 0 {
+		input.Pension.BirthYear = input.SocialSecurity.BirthYear
+		input.Pension.BirthMonth = input.SocialSecurity.BirthMonth
+	}
+	options := []string{"none", "partial", "full"}
+	if input.Pension.BeneficiaryAge > 0 {
+		options = append(options, "insurableInterest")
+	}
+	lifetimePension := func(projection RetirementProjectionResult) float64 {
+		total := 0.0
+		for _, year := range projection.YearlyData {
+			total += year.PensionIncome
+		}
+		return total
+	}
+
+	var baseline, baselineNoPopUp float64
+	for _, option := range options {
+		scenario := input
+		scenario.Pension.SurvivorBenefitOption = option
+		pension := a.CalculatePension(scenario.Pension)
+		projection := a.CalculateRetirementProjection(scenario)
+		scenario.SpouseDeathYear = 0
+		scenario.DivorceYear = 0
+		noPopUp := a.CalculateRetirementProjection(scenario)
+
+		entry := SurvivorElectionOption{
+			Option:            option,
+			AnnualPension:     pension.AnnualPension,
+			SurvivorAnnuity:   pension.SurvivorAnnuity,
+			LifetimePension:   lifetimePension(projection),
+			LifetimeNetIncome: projection.TotalNetIncome,
+		}
+		if pension.PopUpAnnuity > 0 {
+			entry.SurvivorReduction = pension.PopUpAnnuity - pension.AnnualPension
+			for _, year := range projection.YearlyData {
+				if year.SurvivorPopUp {
+					entry.PopUpYear = year.Year
+					break
+				}
+			}
+		}
+		if option == "none" {
+			baseline = entry.LifetimePension
+			baselineNoPopUp = lifetimePension(noPopUp)
+		}
+		entry.LifetimeCost = baseline - entry.LifetimePension
+		entry.LifetimeCostNoPopUp = baselineNoPopUp - lifetimePension(noPopUp)
+		result.Options = append(result.Options, entry)
+	}
+	if input.SpouseDeathYear == 0 && input.DivorceYear == 0 {
+		result.Notes = "No spouse death or divorce assumed; the survivor reduction is charged for the whole projection.\n"
+	} else {
+		result.Notes = "Lifetime cost reflects the unreduced annuity restored after the assumed spouse death or divorce.\n"
+	}
+	return result
+}
+
+// toSocialSecurityCalculationInput converts from API input to calculation model input
+func toSocialSecurityCalculationInput(input SocialSecurityInput) models.SocialSecurityCalculationInput {
+	return models.SocialSecurityCalculationInput{
 		BirthYear:               input.BirthYear,
 		CurrentAge:              input.CurrentAge,
 		EstimatedAnnualSalary:   input.EstimatedAnnualSalary,
 		YearsWorked:             input.YearsWorked,
+		EarningsHistory:         input.EarningsHistory,
+		EarningsStartYear:       input.EarningsStartYear,
+		FutureAnnualEarnings:    input.FutureAnnualEarnings,
+		StopWorkingAge:          input.StopWorkingAge,
+		WageGrowthRate:          input.WageGrowthRate,
+		COLARate:                input.ColaRate,
 		UserProvidedEstimate62:  input.UserProvidedEstimate62,
 		UserProvidedEstimateFRA: input.UserProvidedEstimateFRA,
 		UserProvidedEstimate70:  input.UserProvidedEstimate70,
 		ClaimAge:                input.StartAge,
+		ClaimAgeMonths:          input.StartAgeMonths,
+		BirthMonth:              input.BirthMonth,
+		YearlyEarnedIncome:      input.YearlyEarnedIncome,
+		TrustFund:               toTrustFundPolicy(input.TrustFund),
 	}
+}
+
+// CalculateSocialSecurity computes projected Social Security benefits based on user input
+//export
+func (a *App) CalculateSocialSecurity(input SocialSecurityInput) SocialSecurityResult {
+	ssInput := toSocialSecurityCalculationInput(input)
 
 	// Calculate the Social Security benefit
 	result := calculation.CalculateSocialSecurity(ssInput)
 
-	// Return the result in API format
-	// Calculate full retirement age based on birth year (simplified)
-	fullRetirementAge := 66
-	if input.BirthYear >= 1960 {
-		fullRetirementAge = 67
+	monthlyAfterFRA := result.EarningsTest.MonthlyBenefitAfterFRA
+	if monthlyAfterFRA == 0 {
+		monthlyAfterFRA = result.ClaimingAmount
 	}
-	
+
+	// Return the result in API format
 	return SocialSecurityResult{
 		EstimatedMonthlyAt62:  result.EstimatedAt62,
 		EstimatedMonthlyAtFRA: result.EstimatedAtFRA,
@@ -545,13 +1990,191 @@ func (a *App) CalculateSocialSecurity(input SocialSecurityInput) SocialSecurityR
 		EstimatedAnnualAtFRA:  result.EstimatedAtFRA * 12,
 		EstimatedAnnualAt70:   result.EstimatedAt70 * 12,
 		ClaimingAge:           result.ClaimingAge,
+		ClaimingAgeMonths:     result.ClaimingAgeMonths,
+		ClaimingFactor:        result.ClaimingFactor,
 		ClaimingMonthlyAmount: result.ClaimingAmount,
 		ClaimingAnnualAmount:  result.ClaimingAmount * 12,
-		FullRetirementAge:     fullRetirementAge,
+		FullRetirementAge:     result.FRAYears,
+		FullRetirementAgeMonths: result.FRAMonths,
+		AIME:                  result.AIME,
+		PIA:                   result.PIA,
+		EarningsTestWithheld:  result.EarningsTest.TotalWithheld,
+		MonthsWithheld:        result.EarningsTest.MonthsWithheld,
+		MonthlyAfterFRA:       monthlyAfterFRA,
+		PayableMonthlyAmount:  result.PayableAmount,
+		PayableFromYear:       result.PayableFromYear,
 		Notes:                 result.Notes,
 	}
 }
 
+// SSAEarningsYearData is one year of an imported SSA earnings record
+type SSAEarningsYearData struct {
+	Year             int     `json:"year"`
+	FicaEarnings     float64 `json:"ficaEarnings"`
+	MedicareEarnings float64 `json:"medicareEarnings"`
+	Posted           bool    `json:"posted"` // False if SSA has not yet recorded the year
+}
+
+// SSAStatementImportResult contains the Social Security input read from an SSA earnings record file
+type SSAStatementImportResult struct {
+	Success        bool                  `json:"success"`
+	Message        string                `json:"message"`
+	SocialSecurity SocialSecurityInput   `json:"socialSecurity"`
+	Earnings       []SSAEarningsYearData `json:"earnings"`
+	GapYears       []int                 `json:"gapYears"`      // Years with no earnings between the first and last posted year
+	UnpostedYears  []int                 `json:"unpostedYears"` // Years SSA has not yet recorded
+	Notes          string                `json:"notes"`
+}
+
+// ImportSSAStatement reads a "my Social Security" earnings record XML file from a local path
+//export
+func (a *App) ImportSSAStatement(filePath string) SSAStatementImportResult {
+	xmlData, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return SSAStatementImportResult{
+			Success: false,
+			Message: fmt.Sprintf("Failed to read SSA statement file: %v", err),
+		}
+	}
+
+	imported, err := calculation.ImportSSAStatement(xmlData)
+	if err != nil {
+		return SSAStatementImportResult{
+			Success: false,
+			Message: fmt.Sprintf("Failed to parse SSA statement file: %v", err),
+		}
+	}
+
+	earnings := make([]SSAEarningsYearData, 0, len(imported.Earnings))
+	for _, e := range imported.Earnings {
+		earnings = append(earnings, SSAEarningsYearData{
+			Year:             e.Year,
+			FicaEarnings:     e.FICAEarnings,
+			MedicareEarnings: e.MedicareEarnings,
+			Posted:           e.Posted,
+		})
+	}
+
+	return SSAStatementImportResult{
+		Success: true,
+		Message: fmt.Sprintf("Imported %d years of earnings from %s", len(imported.Input.EarningsHistory), filepath.Base(filePath)),
+		SocialSecurity: SocialSecurityInput{
+			BirthYear:               imported.Input.BirthYear,
+			BirthMonth:              imported.Input.BirthMonth,
+			EarningsHistory:         imported.Input.EarningsHistory,
+			EarningsStartYear:       imported.Input.EarningsStartYear,
+			UserProvidedEstimate62:  imported.Input.UserProvidedEstimate62,
+			UserProvidedEstimateFRA: imported.Input.UserProvidedEstimateFRA,
+			UserProvidedEstimate70:  imported.Input.UserProvidedEstimate70,
+		},
+		Earnings:      earnings,
+		GapYears:      imported.GapYears,
+		UnpostedYears: imported.UnpostedYears,
+		Notes:         imported.Notes,
+	}
+}
+
+// householdMember converts one spouse's Social Security input for household benefits. The PIA is the computed
+// one (before the COLAs in the FRA estimate), the statement's FRA amount, or backed out of the estimated
+// monthly benefit when there is no statement or earnings data.
+func (a *App) householdMember(input SocialSecurityInput) models.HouseholdMember {
+	member := models.HouseholdMember{
+		BirthYear:      input.BirthYear,
+		ClaimAge:       input.StartAge,
+		ClaimAgeMonths: input.StartAgeMonths,
+	}
+	if !input.IsEligible {
+		return member
+	}
+	result := a.CalculateSocialSecurity(input)
+	member.PIA = result.PIA
+	if member.PIA == 0 {
+		member.PIA = result.EstimatedMonthlyAtFRA
+	}
+	if member.PIA == 0 && input.EstimatedMonthlyBenefit > 0 && result.ClaimingFactor > 0 {
+		member.PIA = input.EstimatedMonthlyBenefit / result.ClaimingFactor
+	}
+	return member
+}
+
+// ClaimingStrategyInput contains both spouses' records for the claiming-strategy search
+type ClaimingStrategyInput struct {
+	Worker               SocialSecurityInput `json:"worker"` // startAge is the baseline claiming age
+	Spouse               SocialSecurityInput `json:"spouse"` // startAge is the baseline claiming age
+	DiscountRate         float64             `json:"discountRate"`
+	MortalityModel       string              `json:"mortalityModel"` // "gompertz" (default) or "fixed"
+	WorkerLifeExpectancy int                 `json:"workerLifeExpectancy"`
+	SpouseLifeExpectancy int                 `json:"spouseLifeExpectancy"`
+	EarlyDeathAge        int                 `json:"earlyDeathAge"` // Age one spouse dies in the early-death test (default 70)
+	TopStrategies        int                 `json:"topStrategies"`
+}
+
+// ClaimingStrategyData is one pair of claiming ages and its lifetime value
+type ClaimingStrategyData struct {
+	WorkerClaimAge       int     `json:"workerClaimAge"`
+	WorkerClaimAgeMonths int     `json:"workerClaimAgeMonths"`
+	SpouseClaimAge       int     `json:"spouseClaimAge"`
+	SpouseClaimAgeMonths int     `json:"spouseClaimAgeMonths"`
+	ExpectedValue        float64 `json:"expectedValue"` // Discounted, mortality-weighted lifetime household benefits
+	GainOverBaseline     float64 `json:"gainOverBaseline"`
+	BreakEvenAge         int     `json:"breakEvenAge"` // Worker age by which the strategy catches up with the baseline (0 = n/a)
+	WorkerDiesEarlyValue float64 `json:"workerDiesEarlyValue"`
+	SpouseDiesEarlyValue float64 `json:"spouseDiesEarlyValue"`
+}
+
+// ClaimingStrategyResult contains the top claiming strategies and the baseline they are ranked against
+type ClaimingStrategyResult struct {
+	Strategies          []ClaimingStrategyData `json:"strategies"`
+	Baseline            ClaimingStrategyData   `json:"baseline"`
+	Single              SocialSecurityResult   `json:"single"`      // Worker's single-person result
+	SingleValue         float64                `json:"singleValue"` // Lifetime value of the worker's own benefit alone
+	StrategiesEvaluated int                    `json:"strategiesEvaluated"`
+	Notes               string                 `json:"notes"`
+}
+
+func toClaimingStrategyData(s models.ClaimingStrategy) ClaimingStrategyData {
+	return ClaimingStrategyData{
+		WorkerClaimAge:       s.WorkerClaimAgeMonths / 12,
+		WorkerClaimAgeMonths: s.WorkerClaimAgeMonths % 12,
+		SpouseClaimAge:       s.SpouseClaimAgeMonths / 12,
+		SpouseClaimAgeMonths: s.SpouseClaimAgeMonths % 12,
+		ExpectedValue:        s.ExpectedValue,
+		GainOverBaseline:     s.GainOverBaseline,
+		BreakEvenAge:         s.BreakEvenAge,
+		WorkerDiesEarlyValue: s.WorkerDiesEarlyValue,
+		SpouseDiesEarlyValue: s.SpouseDiesEarlyValue,
+	}
+}
+
+// OptimizeClaimingStrategy searches both spouses' claiming ages from 62 to 70 for the best household strategy
+//export
+func (a *App) OptimizeClaimingStrategy(input ClaimingStrategyInput) ClaimingStrategyResult {
+	optimized := calculation.OptimizeClaimingStrategy(models.ClaimingStrategyInput{
+		Worker:               toSocialSecurityCalculationInput(input.Worker),
+		Spouse:               toSocialSecurityCalculationInput(input.Spouse),
+		DiscountRate:         input.DiscountRate,
+		MortalityModel:       input.MortalityModel,
+		WorkerLifeExpectancy: input.WorkerLifeExpectancy,
+		SpouseLifeExpectancy: input.SpouseLifeExpectancy,
+		EarlyDeathAge:        input.EarlyDeathAge,
+		TopStrategies:        input.TopStrategies,
+	})
+
+	strategies := make([]ClaimingStrategyData, 0, len(optimized.Strategies))
+	for _, s := range optimized.Strategies {
+		strategies = append(strategies, toClaimingStrategyData(s))
+	}
+
+	return ClaimingStrategyResult{
+		Strategies:          strategies,
+		Baseline:            toClaimingStrategyData(optimized.Baseline),
+		Single:              a.CalculateSocialSecurity(input.Worker),
+		SingleValue:         optimized.SingleValue,
+		StrategiesEvaluated: optimized.StrategiesEvaluated,
+		Notes:               optimized.Notes,
+	}
+}
+
 // CalculateTSPProjection projects TSP growth and withdrawals
 //export
 func (a *App) CalculateTSPProjection(input TSPInput) TSPProjectionResult {
@@ -702,7 +2325,13 @@ func (a *App) CalculateTaxLiability(input TaxInput) TaxResult {
 	}
 
 	// Calculate taxable income (simple model)
-	taxableIncome := input.TotalIncome
+	taxableIncome := input.TotalIncome - input.PensionTaxFreeAmount - input.FormerSpouseAward
+	if input.PensionTaxFreeAmount > 0 {
+		result.Notes += fmt.Sprintf("$%.0f of pension is a tax-free recovery of contributions. ", input.PensionTaxFreeAmount)
+	}
+	if input.FormerSpouseAward > 0 {
+		result.Notes += fmt.Sprintf("$%.0f of pension is paid to a former spouse and taxed to them. ", input.FormerSpouseAward)
+	}
 	
 	// Subtract standard deduction based on filing status
 	var standardDeduction float64
@@ -958,6 +2587,8 @@ type ScenarioData struct {
 	Tax            TaxInput           `json:"tax"`
 	COLA           COLAInput          `json:"cola"`
 	OtherIncome    OtherIncomeInput   `json:"otherIncome"`
+	SpouseDeathYear int               `json:"spouseDeathYear"`
+	DivorceYear    int                `json:"divorceYear"`
 }
 
 // ScenariosCollection represents a collection of scenarios
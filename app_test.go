@@ -0,0 +1,424 @@
+package main
+
+import (
+	"ferex/backend/tests/testutils"
+	"testing"
+)
+
+// survivorScenario is a FERS retirement at 62 in 2025 with a married joint return and no COLAs
+func survivorScenario(option string) RetirementScenarioInput {
+	return RetirementScenarioInput{
+		Pension: PensionInput{
+			System:                "FERS",
+			High3Salary:           100000,
+			YearsOfService:        30,
+			AgeAtRetirement:       62,
+			SurvivorBenefitOption: option,
+			BeneficiaryAge:        60,
+		},
+		SocialSecurity: SocialSecurityInput{
+			BirthYear:  1963,
+			BirthMonth: 1,
+		},
+		Tax:                TaxInput{FilingStatus: "married_joint"},
+		ProjectionStartAge: 62,
+		ProjectionEndAge:   75,
+	}
+}
+
+// projectionYear returns the projected data for a calendar year
+func projectionYear(t *testing.T, projection RetirementProjectionResult, year int) YearlyProjectionData {
+	t.Helper()
+	for _, y := range projection.YearlyData {
+		if y.Year == year {
+			return y
+		}
+	}
+	t.Fatalf("year %d not in projection", year)
+	return YearlyProjectionData{}
+}
+
+func TestRetirementProjectionSurvivorPopUp(t *testing.T) {
+	cases := []struct {
+		name            string
+		option          string
+		spouseDeathYear int
+		divorceYear     int
+		expectPopUpYear int // 0 = the reduction is never removed
+		notesContains   string
+	}{
+		{
+			name:            "full election, spouse dies",
+			option:          "full",
+			spouseDeathYear: 2030,
+			expectPopUpYear: 2031,
+			notesContains:   "Survivor reduction removed from 2031",
+		},
+		{
+			name:            "partial election, divorce before the spouse's death",
+			option:          "partial",
+			spouseDeathYear: 2033,
+			divorceYear:     2028,
+			expectPopUpYear: 2029,
+			notesContains:   "Survivor reduction removed from 2029",
+		},
+		{
+			name:            "insurable interest, divorce does not end the election",
+			option:          "insurableInterest",
+			divorceYear:     2028,
+			expectPopUpYear: 0,
+			notesContains:   "Divorce does not end an insurable interest election",
+		},
+		{
+			name:            "insurable interest, beneficiary dies",
+			option:          "insurableInterest",
+			spouseDeathYear: 2030,
+			divorceYear:     2028,
+			expectPopUpYear: 2031,
+			notesContains:   "Survivor reduction removed from 2031",
+		},
+		{
+			name:            "no election, nothing to restore",
+			option:          "none",
+			spouseDeathYear: 2030,
+			expectPopUpYear: 0,
+		},
+	}
+	app := NewApp()
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			input := survivorScenario(c.option)
+			input.SpouseDeathYear = c.spouseDeathYear
+			input.DivorceYear = c.divorceYear
+			pension := app.CalculatePension(input.Pension)
+			projection := app.CalculateRetirementProjection(input)
+
+			popUpYear := 0
+			for _, y := range projection.YearlyData {
+				if y.SurvivorPopUp {
+					popUpYear = y.Year
+					break
+				}
+			}
+			if popUpYear != c.expectPopUpYear {
+				t.Errorf("pop-up year: got %d, want %d", popUpYear, c.expectPopUpYear)
+			}
+			if c.expectPopUpYear > 0 {
+				// Without COLAs the pension steps up by exactly the survivor reduction
+				before := projectionYear(t, projection, c.expectPopUpYear-1).PensionIncome
+				after := projectionYear(t, projection, c.expectPopUpYear).PensionIncome
+				if testutils.Abs(before-pension.AnnualPension) > 0.01 {
+					t.Errorf("reduced pension: got %.2f, want %.2f", before, pension.AnnualPension)
+				}
+				if testutils.Abs(after-pension.PopUpAnnuity) > 0.01 {
+					t.Errorf("restored pension: got %.2f, want %.2f", after, pension.PopUpAnnuity)
+				}
+			}
+			if c.notesContains != "" && !testutils.Contains(projection.Notes, c.notesContains) {
+				t.Errorf("notes missing %q: %s", c.notesContains, projection.Notes)
+			}
+		})
+	}
+}
+
+func TestRetirementProjectionPopUpCarriesCOLAs(t *testing.T) {
+	cases := []struct {
+		name   string
+		system string
+	}{
+		{name: "FERS", system: "FERS"},
+		{name: "FERS transferee components", system: "FERS Transferee"},
+	}
+	app := NewApp()
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			input := survivorScenario("full")
+			input.Pension.System = c.system
+			if c.system == "FERS Transferee" {
+				input.Pension.CSRSYearsOfService = 12
+				input.Pension.FERSYearsOfService = 18
+			}
+			input.COLA = COLAInput{AssumedInflationRate: 0.03, ApplyCOLAToPension: true}
+			input.SpouseDeathYear = 2030
+			reduced := app.CalculateRetirementProjection(input)
+			input.Pension.SurvivorBenefitOption = "none"
+			unreduced := app.CalculateRetirementProjection(input)
+
+			// After the pop-up the annuity matches one that was never reduced, COLAs included
+			for _, year := range []int{2031, 2035} {
+				got := projectionYear(t, reduced, year).PensionIncome
+				want := projectionYear(t, unreduced, year).PensionIncome
+				if testutils.Abs(got-want) > 0.01 {
+					t.Errorf("%d: got %.2f, want %.2f", year, got, want)
+				}
+			}
+			if got, want := projectionYear(t, reduced, 2030).PensionIncome, projectionYear(t, unreduced, 2030).PensionIncome; got >= want {
+				t.Errorf("2030: reduced pension %.2f should be below %.2f", got, want)
+			}
+		})
+	}
+}
+
+func TestRetirementProjectionFilingStatus(t *testing.T) {
+	cases := []struct {
+		name            string
+		spouseDeathYear int
+		divorceYear     int
+		expect          map[int]string
+	}{
+		{
+			name:            "joint through the year of death",
+			spouseDeathYear: 2030,
+			expect:          map[int]string{2029: "married_joint", 2030: "married_joint", 2031: "single"},
+		},
+		{
+			name:        "single from the year of divorce",
+			divorceYear: 2028,
+			expect:      map[int]string{2027: "married_joint", 2028: "single", 2031: "single"},
+		},
+	}
+	app := NewApp()
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			input := survivorScenario("full")
+			input.SpouseDeathYear = c.spouseDeathYear
+			input.DivorceYear = c.divorceYear
+			projection := app.CalculateRetirementProjection(input)
+			for year, status := range c.expect {
+				if got := projectionYear(t, projection, year).FilingStatus; got != status {
+					t.Errorf("%d: got %q, want %q", year, got, status)
+				}
+			}
+		})
+	}
+}
+
+func TestCompareSurvivorElections(t *testing.T) {
+	app := NewApp()
+	input := survivorScenario("none")
+	input.SpouseDeathYear = 2030
+	result := app.CompareSurvivorElections(input)
+
+	if len(result.Options) != 4 {
+		t.Fatalf("expected 4 options, got %d", len(result.Options))
+	}
+	byOption := make(map[string]SurvivorElectionOption)
+	for _, o := range result.Options {
+		byOption[o.Option] = o
+	}
+	if none := byOption["none"]; none.LifetimeCost != 0 || none.PopUpYear != 0 {
+		t.Errorf("no election: cost %.2f, pop-up %d", none.LifetimeCost, none.PopUpYear)
+	}
+	full, partial := byOption["full"], byOption["partial"]
+	if full.PopUpYear != 2031 {
+		t.Errorf("full pop-up year: got %d, want 2031", full.PopUpYear)
+	}
+	// Six reduced years (2025-2030) against fourteen without the pop-up
+	if testutils.Abs(full.LifetimeCost-6*full.SurvivorReduction) > 0.01 {
+		t.Errorf("full cost: got %.2f, want %.2f", full.LifetimeCost, 6*full.SurvivorReduction)
+	}
+	if testutils.Abs(full.LifetimeCostNoPopUp-14*full.SurvivorReduction) > 0.01 {
+		t.Errorf("full cost without pop-up: got %.2f, want %.2f", full.LifetimeCostNoPopUp, 14*full.SurvivorReduction)
+	}
+	if partial.LifetimeCost <= 0 || partial.LifetimeCost >= full.LifetimeCost {
+		t.Errorf("partial cost %.2f should be between 0 and %.2f", partial.LifetimeCost, full.LifetimeCost)
+	}
+	if !testutils.Contains(result.Notes, "restored after the assumed spouse death") {
+		t.Errorf("unexpected notes: %s", result.Notes)
+	}
+}
+
+func TestHouseholdMemberPIA(t *testing.T) {
+	cases := []struct {
+		name  string
+		input SocialSecurityInput
+	}{
+		{
+			name: "computed from earnings: the PIA, not the FRA benefit with COLAs",
+			input: SocialSecurityInput{
+				BirthYear:             1960,
+				CurrentAge:            65,
+				EstimatedAnnualSalary: 60000,
+				YearsWorked:           35,
+				StartAge:              67,
+				IsEligible:            true,
+			},
+		},
+		{
+			name: "SSA statement: the FRA amount",
+			input: SocialSecurityInput{
+				BirthYear:               1960,
+				UserProvidedEstimate62:  1400,
+				UserProvidedEstimateFRA: 2000,
+				UserProvidedEstimate70:  2480,
+				StartAge:                67,
+				IsEligible:              true,
+			},
+		},
+	}
+	app := NewApp()
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			result := app.CalculateSocialSecurity(c.input)
+			want := result.PIA
+			if want == 0 {
+				want = result.EstimatedMonthlyAtFRA
+			}
+			member := app.householdMember(c.input)
+			if want <= 0 || testutils.Abs(member.PIA-want) > 0.01 {
+				t.Errorf("PIA: got %.2f, want %.2f", member.PIA, want)
+			}
+		})
+	}
+}
+
+func TestRetirementProjectionEarnedIncomeEarningsTest(t *testing.T) {
+	age63 := 63
+	input := survivorScenario("none")
+	input.SocialSecurity.IsEligible = true
+	input.SocialSecurity.StartAge = 62
+	input.SocialSecurity.EstimatedMonthlyBenefit = 1750
+	input.COLA = COLAInput{AssumedInflationRate: 0.03, ApplyColaToSocialSecurity: true}
+	input.OtherIncome.Sources = []OtherIncomeSource{
+		{Name: "Consulting", Amount: 44120, Frequency: "annual", StartAge: 63, EndAge: &age63, Type: "earned"},
+		{Name: "Rental", Amount: 30000, Frequency: "annual", StartAge: 62},
+	}
+	projection := NewApp().CalculateRetirementProjection(input)
+
+	// 2026 limit $24,120 (2025's $23,400 grown 3%): ($44,120 - $24,120) / 2 withheld from the benefit with its COLA
+	year := projectionYear(t, projection, 2026)
+	if testutils.Abs(year.SSWithheld-10000) > 0.01 {
+		t.Errorf("withheld: got %.2f, want 10000", year.SSWithheld)
+	}
+	if want := 1750*12*1.03 - 10000; testutils.Abs(year.SocialSecurity-want) > 0.01 {
+		t.Errorf("Social Security: got %.2f, want %.2f", year.SocialSecurity, want)
+	}
+	if year.EarnedIncome != 44120 || year.OtherIncome != 74120 {
+		t.Errorf("earned %.2f of other income %.2f, want 44120 of 74120", year.EarnedIncome, year.OtherIncome)
+	}
+	// Unearned income is not tested
+	if year := projectionYear(t, projection, 2025); year.SSWithheld != 0 || year.EarnedIncome != 0 {
+		t.Errorf("2025: withheld %.2f on earned income %.2f, want none", year.SSWithheld, year.EarnedIncome)
+	}
+}
+
+func TestRetirementProjectionCSRSMilitaryRemovedAt62(t *testing.T) {
+	input := RetirementScenarioInput{
+		Pension: PensionInput{
+			System:          "CSRS",
+			High3Salary:     100000,
+			AgeAtRetirement: 56,
+			ServicePeriods: []ServicePeriodInput{
+				{StartDate: "1970-01-01", EndDate: "1973-12-31", ServiceType: "military"},
+				{StartDate: "1974-01-01", EndDate: "2003-12-31", ServiceType: "fulltime"},
+			},
+		},
+		SocialSecurity:     SocialSecurityInput{BirthYear: 1969, BirthMonth: 1, IsEligible: true},
+		Tax:                TaxInput{FilingStatus: "single"},
+		ProjectionStartAge: 56,
+		ProjectionEndAge:   65,
+	}
+	projection := NewApp().CalculateRetirementProjection(input)
+
+	pensionAt := func(projection RetirementProjectionResult, age int) float64 {
+		for _, y := range projection.YearlyData {
+			if y.Age == age {
+				return y.PensionIncome
+			}
+		}
+		t.Fatalf("age %d not in projection", age)
+		return 0
+	}
+
+	// 34 years until 62, then 30 years once the military service without a deposit is removed
+	for age, want := range map[int]float64{61: 64250, 62: 56250, 65: 56250} {
+		if got := pensionAt(projection, age); testutils.Abs(got-want) > 0.01 {
+			t.Errorf("age %d: got %.2f, want %.2f", age, got, want)
+		}
+	}
+
+	input.SocialSecurity.IsEligible = false
+	projection = NewApp().CalculateRetirementProjection(input)
+	if got := pensionAt(projection, 62); testutils.Abs(got-64250) > 0.01 {
+		t.Errorf("not eligible for Social Security, age 62: got %.2f, want 64250", got)
+	}
+}
+
+func TestPhasedRetirementResolvesPensionInput(t *testing.T) {
+	cases := []struct {
+		name         string
+		pension      PensionInput
+		expectPhased float64
+	}{
+		{
+			name: "paid military deposit brings 18 years to 20",
+			pension: PensionInput{
+				System:              "FERS",
+				High3Salary:         100000,
+				YearsOfService:      18,
+				MilitaryService:     2,
+				MilitaryDepositPaid: true,
+			},
+			expectPhased: 10000, // 50% of 1% x 20 x 100000
+		},
+		{
+			name: "service history and salary history",
+			pension: PensionInput{
+				System: "FERS",
+				ServicePeriods: []ServicePeriodInput{
+					{StartDate: "2005-01-01", EndDate: "2024-12-31", ServiceType: "fulltime"},
+				},
+				SalaryHistory:  []SalaryRateInput{{EffectiveDate: "2015-01-01", AnnualRate: 120000}},
+				RetirementDate: "2024-12-31",
+			},
+			expectPhased: 12000, // 50% of 1% x 20 x 120000
+		},
+	}
+	app := NewApp()
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			c.pension.AgeAtRetirement = 60
+			c.pension.BirthYear = 1964
+			result := app.CalculatePhasedRetirement(PhasedRetirementInput{Pension: c.pension, FullTimeSalary: 100000, PhasedYears: 2})
+			if !result.IsEligible {
+				t.Fatalf("expected eligible: %s", result.Notes)
+			}
+			if testutils.Abs(result.PhasedAnnuity-c.expectPhased) > 0.01 {
+				t.Errorf("phased annuity: got %.2f, want %.2f (%s)", result.PhasedAnnuity, c.expectPhased, result.Notes)
+			}
+		})
+	}
+}
+
+func TestFERSTransfereeServicePeriods(t *testing.T) {
+	result := NewApp().CalculatePension(PensionInput{
+		System:               "FERS Transferee",
+		High3Salary:          100000,
+		AgeAtRetirement:      60,
+		BirthYear:            1965,
+		UnusedSickLeaveHours: 1044,
+		ServicePeriods:       []ServicePeriodInput{{StartDate: "1985-01-01", EndDate: "2014-12-31", ServiceType: "fulltime"}},
+		TransferDate:         "1995-01-01",
+	})
+	// 10 CSRS years plus 6 months of sick leave and 20 FERS years
+	if testutils.Abs(result.AnnualPension-37250) > 0.01 {
+		t.Errorf("annual pension: got %.2f, want 37250 (%s)", result.AnnualPension, result.Notes)
+	}
+	if result.ServiceYears != 30 || result.FERSServiceYears != 20 {
+		t.Errorf("service: got %.2f with %.2f FERS, want 30 with 20", result.ServiceYears, result.FERSServiceYears)
+	}
+}
+
+func TestCSRSPensionAgeAtRetirementMonths(t *testing.T) {
+	result := NewApp().CalculatePension(PensionInput{
+		System:                "CSRS",
+		High3Salary:           100000,
+		YearsOfService:        30,
+		AgeAtRetirement:       54,
+		AgeAtRetirementMonths: 6,
+	})
+	// Six months under 55 at 2% per year: a 1% reduction of $56,250
+	if want := 56250 * 0.99; testutils.Abs(result.AnnualPension-want) > 0.01 {
+		t.Errorf("annual pension: got %.2f, want %.2f (%s)", result.AnnualPension, want, result.Notes)
+	}
+}
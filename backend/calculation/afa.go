@@ -0,0 +1,53 @@
+package calculation
+
+import (
+	"ferex/backend/models"
+	"fmt"
+)
+
+// CalculateAlternativeFormOfAnnuity pays employee contributions as a lump sum and reduces the annuity by its actuarial equivalent.
+func CalculateAlternativeFormOfAnnuity(input models.AlternativeFormOfAnnuityInput) models.AlternativeFormOfAnnuityResult {
+	var notes string
+	var fullAnnuity, lumpSum float64
+	var age int
+	if input.PensionType == "FERS" {
+		fers := CalculateFERSPension(input.FERSInput)
+		if fers.RetirementType == "Disability" {
+			return models.AlternativeFormOfAnnuityResult{FullAnnuity: fers.AnnualPension, Notes: "The Alternative Form of Annuity is not available with a disability retirement.\n"}
+		}
+		fullAnnuity, lumpSum, age = fers.AnnualPension, input.FERSInput.EmployeeContributions, input.FERSInput.AgeAtRetirement
+	} else {
+		csrs := CalculateCSRS(input.CSRSInput)
+		if input.CSRSInput.RetirementOption == "Disability" {
+			return models.AlternativeFormOfAnnuityResult{FullAnnuity: csrs.AnnualPension, Notes: "The Alternative Form of Annuity is not available with a disability retirement.\n"}
+		}
+		fullAnnuity, lumpSum, age = csrs.AnnualPension, input.CSRSInput.EmployeeContributions, input.CSRSInput.AgeAtRetirement
+	}
+
+	if !input.HasLifeThreateningAffliction {
+		notes += "The Alternative Form of Annuity is only available to retirees with a life-threatening affliction.\n"
+	}
+	if lumpSum <= 0 {
+		notes += "No employee contributions provided; there is no lump sum.\n"
+	}
+
+	factor := presentValueFactor(age)
+	reduction := lumpSum / factor * 12
+	if reduction > fullAnnuity {
+		reduction = fullAnnuity
+	}
+	reduced := fullAnnuity - reduction
+	notes += fmt.Sprintf("Estimate: lump sum of $%.2f reduces the annuity by about $%.2f/yr (approximate present value factor %.1f at age %d, not OPM's published factor).\n", lumpSum, reduction, factor, age)
+
+	return models.AlternativeFormOfAnnuityResult{
+		IsAvailable:        input.HasLifeThreateningAffliction && lumpSum > 0,
+		FullAnnuity:        fullAnnuity,
+		LumpSum:            lumpSum,
+		PresentValueFactor: factor,
+		IsEstimate:         true,
+		AnnualReduction:    reduction,
+		ReducedAnnuity:     reduced,
+		MonthlyAnnuity:     reduced / 12.0,
+		Notes:              notes,
+	}
+}
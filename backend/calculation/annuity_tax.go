@@ -0,0 +1,117 @@
+package calculation
+
+import (
+	"ferex/backend/models"
+	"fmt"
+	"math"
+)
+
+// simplifiedMethodDivisor returns the IRS anticipated number of payments for a single life or, with a beneficiary, the combined ages.
+func simplifiedMethodDivisor(age, beneficiaryAge int) (divisor int, joint bool) {
+	if beneficiaryAge > 0 {
+		combined := age + beneficiaryAge
+		switch {
+		case combined <= 110:
+			return 410, true
+		case combined <= 120:
+			return 360, true
+		case combined <= 130:
+			return 310, true
+		case combined <= 140:
+			return 260, true
+		default:
+			return 210, true
+		}
+	}
+	switch {
+	case age <= 55:
+		return 360, false
+	case age <= 60:
+		return 310, false
+	case age <= 65:
+		return 260, false
+	case age <= 70:
+		return 210, false
+	default:
+		return 160, false
+	}
+}
+
+// monthlyTaxFree returns the Simplified Method exclusion for a pension, with the joint-life divisor when a survivor annuity is elected.
+func monthlyTaxFree(contributions, monthlyAnnuity float64, age, beneficiaryAge int, election string) (float64, string) {
+	if contributions <= 0 {
+		return 0, ""
+	}
+	if election != "max" && election != "partial" && election != "insurable interest" {
+		beneficiaryAge = 0
+	}
+	result := CalculateSimplifiedMethod(models.SimplifiedMethodInput{
+		EmployeeContributions: contributions,
+		MonthlyAnnuity:        monthlyAnnuity,
+		AgeAtAnnuityStart:     age,
+		BeneficiaryAge:        beneficiaryAge,
+	})
+	return result.MonthlyTaxFree, result.Notes
+}
+
+// CalculateSimplifiedMethod computes the tax-free part of each annuity payment and tracks the unrecovered cost year by year.
+func CalculateSimplifiedMethod(input models.SimplifiedMethodInput) models.SimplifiedMethodResult {
+	var notes string
+	if input.EmployeeContributions <= 0 || input.MonthlyAnnuity <= 0 {
+		return models.SimplifiedMethodResult{Notes: "No employee contributions to recover; the annuity is fully taxable.\n"}
+	}
+	divisor, joint := simplifiedMethodDivisor(input.AgeAtAnnuityStart, input.BeneficiaryAge)
+	monthlyTaxFree := math.Min(input.EmployeeContributions/float64(divisor), input.MonthlyAnnuity)
+	table := "single-life"
+	if joint {
+		table = "joint-life"
+	}
+	notes += fmt.Sprintf("Simplified Method (%s table): $%.2f / %d payments = $%.2f tax-free per month.\n", table, input.EmployeeContributions, divisor, monthlyTaxFree)
+
+	startMonth := input.StartMonth
+	if startMonth < 1 || startMonth > 12 {
+		startMonth = 1
+	}
+	years := len(input.YearlyGrossAnnuity)
+	if years == 0 {
+		years = input.Years
+	}
+
+	schedule := make([]models.SimplifiedMethodYear, years)
+	remaining := input.EmployeeContributions
+	completeYear := 0
+	for i := 0; i < years; i++ {
+		months := 12
+		if i == 0 {
+			months = 13 - startMonth
+		}
+		gross := input.MonthlyAnnuity * float64(months)
+		if len(input.YearlyGrossAnnuity) > 0 {
+			gross = input.YearlyGrossAnnuity[i]
+		}
+		taxFree := math.Min(math.Min(monthlyTaxFree*float64(months), remaining), gross)
+		remaining -= taxFree
+		year := input.StartYear + i
+		if remaining <= 0.005 && completeYear == 0 {
+			remaining = 0
+			completeYear = year
+			notes += fmt.Sprintf("Contributions fully recovered in %d; the annuity is fully taxable after that.\n", year)
+		}
+		schedule[i] = models.SimplifiedMethodYear{
+			Year:            year,
+			GrossAnnuity:    gross,
+			TaxFree:         taxFree,
+			Taxable:         gross - taxFree,
+			UnrecoveredCost: remaining,
+		}
+	}
+
+	return models.SimplifiedMethodResult{
+		Divisor:              divisor,
+		JointLife:            joint,
+		MonthlyTaxFree:       monthlyTaxFree,
+		Schedule:             schedule,
+		RecoveryCompleteYear: completeYear,
+		Notes:                notes,
+	}
+}
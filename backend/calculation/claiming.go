@@ -0,0 +1,243 @@
+package calculation
+
+import (
+	"ferex/backend/models"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Oldest age valued when searching claiming strategies
+const claimingMaxAge = 110
+
+// lifeTable gives a spouse's chance of receiving benefits during each year of age.
+type lifeTable []float64
+
+// newLifeTable builds survival from fromAge through claimingMaxAge; a deathAge fixes the death during that age.
+func newLifeTable(model string, fromAge, lifeExpectancy, deathAge int) lifeTable {
+	table := make(lifeTable, claimingMaxAge+2)
+	for age := range table {
+		switch {
+		case deathAge > 0 && age <= deathAge:
+			table[age] = 1
+		case deathAge > 0:
+			table[age] = 0
+		default:
+			table[age] = survivalProbability(model, fromAge, age, lifeExpectancy)
+		}
+	}
+	return table
+}
+
+func (l lifeTable) alive(age int) float64 {
+	if age >= len(l) {
+		return 0
+	}
+	return l[max(age, 0)]
+}
+
+// householdValue returns the discounted, mortality-weighted lifetime benefits of a claiming strategy and the
+// household benefits for each year both spouses live. Years run by the worker's age from startAge.
+func householdValue(hh models.HouseholdSocialSecurityInput, worker, spouse lifeTable, startAge int, discount float64) (float64, []float64) {
+	offset := hh.Worker.BirthYear - hh.Spouse.BirthYear // Spouse's age is the worker's plus offset
+	endAge := max(claimingMaxAge, claimingMaxAge-offset)
+	factors := make([]float64, endAge-startAge+1)
+	for i := range factors {
+		factors[i] = 1 / math.Pow(1+discount, float64(i))
+	}
+	factor := func(age int) float64 {
+		return factors[age-startAge]
+	}
+	// Each spouse's benefits in the year the worker reaches age, after any trust fund shortfall
+	workerShare := func(age int) float64 {
+		return TrustFundPayableShare(hh.TrustFund, hh.Worker.BirthYear+age, hh.Worker.BirthYear)
+	}
+	spouseShare := func(age int) float64 {
+		return TrustFundPayableShare(hh.TrustFund, hh.Worker.BirthYear+age, hh.Spouse.BirthYear)
+	}
+
+	value := 0.0
+	both := CalculateHouseholdSocialSecurity(hh)
+	yearly := make([]float64, 0, endAge-startAge+1)
+	for age := startAge; age <= endAge; age++ {
+		workerAmount, spouseAmount := 0.0, 0.0
+		for month := 0; month < 12; month++ {
+			workerAmount += memberBenefitAt(both.Worker, age*12+month, true)
+			spouseAmount += memberBenefitAt(both.Spouse, (age+offset)*12+month, true)
+		}
+		amount := workerAmount*workerShare(age) + spouseAmount*spouseShare(age)
+		yearly = append(yearly, amount)
+		value += amount * worker.alive(age) * spouse.alive(age+offset) * factor(age)
+	}
+
+	// One spouse dies during age d; the other continues on the larger of the two benefits
+	for d := startAge; d < endAge; d++ {
+		if q := worker.alive(d) - worker.alive(d+1); q > 1e-9 {
+			widowed := hh.Spouse
+			deceased := hh.Worker
+			deceased.DeathAge = d
+			survivor, _ := householdMemberBenefits(widowed, deceased)
+			for age := d + 1; age <= endAge; age++ {
+				amount := 0.0
+				for month := 0; month < 12; month++ {
+					amount += memberBenefitAt(survivor, (age+offset)*12+month, false)
+				}
+				value += amount * spouseShare(age) * q * spouse.alive(age+offset) * factor(age)
+			}
+		}
+		if q := spouse.alive(d+offset) - spouse.alive(d+offset+1); q > 1e-9 {
+			widowed := hh.Worker
+			deceased := hh.Spouse
+			deceased.DeathAge = d + offset
+			survivor, _ := householdMemberBenefits(widowed, deceased)
+			for age := d + 1; age <= endAge; age++ {
+				amount := 0.0
+				for month := 0; month < 12; month++ {
+					amount += memberBenefitAt(survivor, age*12+month, false)
+				}
+				value += amount * workerShare(age) * q * worker.alive(age) * factor(age)
+			}
+		}
+	}
+	return value, yearly
+}
+
+// breakEvenAge returns the worker age by which cumulative benefits catch up with the baseline after falling behind.
+func breakEvenAge(yearly, baseline []float64, startAge int) int {
+	cumulative, baselineCumulative := 0.0, 0.0
+	behind := false
+	for i := range yearly {
+		cumulative += yearly[i]
+		baselineCumulative += baseline[i]
+		if cumulative < baselineCumulative-0.005 {
+			behind = true
+		} else if behind {
+			return startAge + i
+		}
+	}
+	return 0
+}
+
+// memberPIA returns a spouse's PIA from the single-person calculation.
+func memberPIA(result models.SocialSecurityCalculationResult) float64 {
+	if result.PIA > 0 {
+		return result.PIA
+	}
+	return result.EstimatedAtFRA
+}
+
+// OptimizeClaimingStrategy searches both spouses' claiming ages, month by month from 62 to 70, for the highest
+// discounted, mortality-weighted lifetime household benefits, ranked against claiming at the input ages.
+func OptimizeClaimingStrategy(input models.ClaimingStrategyInput) models.ClaimingStrategyResult {
+	var notes string
+	model := input.MortalityModel
+	if model == "" {
+		model = "gompertz"
+	}
+	workerLE := input.WorkerLifeExpectancy
+	if workerLE == 0 {
+		workerLE = 85
+	}
+	spouseLE := input.SpouseLifeExpectancy
+	if spouseLE == 0 {
+		spouseLE = 85
+	}
+	earlyDeath := input.EarlyDeathAge
+	if earlyDeath == 0 {
+		earlyDeath = 70
+	}
+	top := input.TopStrategies
+	if top == 0 {
+		top = 5
+	}
+
+	single := CalculateSocialSecurity(input.Worker)
+	spouseSingle := CalculateSocialSecurity(input.Spouse)
+	hh := models.HouseholdSocialSecurityInput{
+		Worker:    models.HouseholdMember{BirthYear: input.Worker.BirthYear, PIA: memberPIA(single)},
+		Spouse:    models.HouseholdMember{BirthYear: input.Spouse.BirthYear, PIA: memberPIA(spouseSingle)},
+		TrustFund: input.Worker.TrustFund,
+	}
+
+	if hh.Worker.PIA == 0 || hh.Spouse.PIA == 0 {
+		notes += "One spouse has no statement or earnings data; only spousal and survivor benefits are counted for them.\n"
+	}
+
+	// Value from when the first spouse can claim, or from today if later
+	offset := hh.Worker.BirthYear - hh.Spouse.BirthYear
+	startAge := max(min(62, 62-offset), input.Worker.CurrentAge)
+	worker := newLifeTable(model, startAge, workerLE, 0)
+	spouse := newLifeTable(model, startAge+offset, spouseLE, 0)
+
+	evaluate := func(workerClaim, spouseClaim int) (models.ClaimingStrategy, []float64) {
+		strategy := hh
+		strategy.Worker.ClaimAge, strategy.Worker.ClaimAgeMonths = workerClaim/12, workerClaim%12
+		strategy.Spouse.ClaimAge, strategy.Spouse.ClaimAgeMonths = spouseClaim/12, spouseClaim%12
+		value, yearly := householdValue(strategy, worker, spouse, startAge, input.DiscountRate)
+		return models.ClaimingStrategy{
+			WorkerClaimAgeMonths: workerClaim,
+			SpouseClaimAgeMonths: spouseClaim,
+			ExpectedValue:        value,
+		}, yearly
+	}
+	earlyDeathValues := func(s *models.ClaimingStrategy) {
+		strategy := hh
+		strategy.Worker.ClaimAge, strategy.Worker.ClaimAgeMonths = s.WorkerClaimAgeMonths/12, s.WorkerClaimAgeMonths%12
+		strategy.Spouse.ClaimAge, strategy.Spouse.ClaimAgeMonths = s.SpouseClaimAgeMonths/12, s.SpouseClaimAgeMonths%12
+		s.WorkerDiesEarlyValue, _ = householdValue(strategy, newLifeTable(model, 0, 0, earlyDeath), spouse, startAge, input.DiscountRate)
+		s.SpouseDiesEarlyValue, _ = householdValue(strategy, worker, newLifeTable(model, 0, 0, earlyDeath), startAge, input.DiscountRate)
+	}
+
+	baselineWorker := single.ClaimingAge*12 + single.ClaimingAgeMonths
+	baselineSpouse := spouseSingle.ClaimingAge*12 + spouseSingle.ClaimingAgeMonths
+	baseline, baselineYearly := evaluate(baselineWorker, baselineSpouse)
+	earlyDeathValues(&baseline)
+
+	// Claims cannot start before 62 or before the valuation starts
+	var strategies []models.ClaimingStrategy
+	for w := max(62, startAge) * 12; w <= 70*12; w++ {
+		for s := max(62, startAge+offset) * 12; s <= 70*12; s++ {
+			strategy, _ := evaluate(w, s)
+			strategy.GainOverBaseline = strategy.ExpectedValue - baseline.ExpectedValue
+			strategies = append(strategies, strategy)
+		}
+	}
+	evaluated := len(strategies)
+	sort.SliceStable(strategies, func(i, j int) bool { return strategies[i].ExpectedValue > strategies[j].ExpectedValue })
+	if len(strategies) > top {
+		strategies = strategies[:top]
+	}
+	for i := range strategies {
+		s := &strategies[i]
+		_, yearly := evaluate(s.WorkerClaimAgeMonths, s.SpouseClaimAgeMonths)
+		s.BreakEvenAge = breakEvenAge(yearly, baselineYearly, startAge)
+		earlyDeathValues(s)
+	}
+
+	// The worker's own benefit alone, as the single-person calculator sees it
+	singleValue := 0.0
+	for age := startAge; age <= claimingMaxAge; age++ {
+		months := 12 - min(max(baselineWorker-age*12, 0), 12)
+		monthly := memberPIA(single) * claimingFactor(baselineWorker, single.FRAYears*12+single.FRAMonths)
+		singleValue += monthly * float64(months) * worker.alive(age) / math.Pow(1+input.DiscountRate, float64(age-startAge))
+	}
+
+	notes += fmt.Sprintf("Searched %d claiming-age pairs (%s mortality, %.2f%% discount).\n", evaluated, model, input.DiscountRate*100)
+	if len(strategies) > 0 {
+		best := strategies[0]
+		notes += fmt.Sprintf("Best: worker at %d years %d months, spouse at %d years %d months, $%.0f more than the baseline.\n",
+			best.WorkerClaimAgeMonths/12, best.WorkerClaimAgeMonths%12, best.SpouseClaimAgeMonths/12, best.SpouseClaimAgeMonths%12, best.GainOverBaseline)
+		if best.WorkerDiesEarlyValue < baseline.WorkerDiesEarlyValue {
+			notes += fmt.Sprintf("If the worker dies at %d, the best strategy pays $%.0f less than the baseline.\n", earlyDeath, baseline.WorkerDiesEarlyValue-best.WorkerDiesEarlyValue)
+		}
+	}
+
+	return models.ClaimingStrategyResult{
+		Strategies:          strategies,
+		Baseline:            baseline,
+		Single:              single,
+		SingleValue:         singleValue,
+		StrategiesEvaluated: evaluated,
+		Notes:               notes,
+	}
+}
@@ -0,0 +1,114 @@
+package calculation
+
+import (
+	"ferex/backend/models"
+	"fmt"
+	"math"
+)
+
+// survivorTerms returns the survivor percentage and the cost of a survivor annuity on a base.
+func survivorTerms(pensionType string, base float64) (pct, cost float64) {
+	if pensionType == "FERS" {
+		return 0.50, base * 0.10
+	}
+	return 0.55, csrsSurvivorCost(base)
+}
+
+// CalculateCOAP splits an annuity under a court order and combines current and former spouse survivor annuities within the cap.
+func CalculateCOAP(input models.COAPCalculationInput) models.COAPCalculationResult {
+	var notes string
+	gross := input.GrossAnnuity
+
+	// Former spouse survivor base comes first; the current spouse gets what is left under the cap
+	formerBase := math.Min(input.FormerSpouseSurvivorBase, gross)
+	currentBase := 0.0
+	switch input.CurrentSpouseElection {
+	case "max":
+		currentBase = gross
+	case "partial":
+		currentBase = input.CurrentSpouseBase
+		if currentBase <= 0 {
+			currentBase = gross / 2
+		}
+	case "insurable interest":
+		notes += "Insurable interest elections are not combined with a court order here; no current survivor annuity modeled.\n"
+	}
+	capApplied := false
+	if formerBase+currentBase > gross {
+		currentBase = gross - formerBase
+		capApplied = true
+		notes += fmt.Sprintf("Combined survivor annuities are capped: current spouse base limited to $%.2f.\n", currentBase)
+	}
+	pct, cost := survivorTerms(input.PensionType, formerBase+currentBase)
+	_, formerCost := survivorTerms(input.PensionType, formerBase)
+	formerSurvivor := formerBase * pct
+	currentSurvivor := currentBase * pct
+	if formerBase > 0 {
+		notes += fmt.Sprintf("Former spouse survivor annuity: %.0f%% of $%.2f = $%.2f.\n", pct*100, formerBase, formerSurvivor)
+	}
+	afterSurvivor := gross - cost
+
+	// Basis for percentage and marital fraction awards
+	basis := gross
+	if input.Award.Basis == "net" {
+		basis = math.Max(afterSurvivor-input.HealthPremiums-input.LifeInsurancePremiums, 0)
+	}
+	award := 0.0
+	switch input.Award.AwardType {
+	case "fixed":
+		award = input.Award.FixedMonthlyAmount * 12
+	case "percentage":
+		award = basis * input.Award.Percentage
+	case "marital fraction":
+		if input.TotalServiceYears > 0 {
+			fraction := math.Min(input.Award.MaritalServiceYears/input.TotalServiceYears, 1)
+			award = basis * input.Award.Percentage * fraction
+			notes += fmt.Sprintf("Marital fraction: %.2f / %.2f years = %.4f.\n", input.Award.MaritalServiceYears, input.TotalServiceYears, fraction)
+		} else {
+			notes += "Total service is required for a marital fraction award.\n"
+		}
+	case "":
+	default:
+		notes += fmt.Sprintf("Unknown award type %q ignored.\n", input.Award.AwardType)
+	}
+	award = math.Min(award, afterSurvivor)
+	if award > 0 {
+		basisName := input.Award.Basis
+		if basisName == "" {
+			basisName = "gross"
+		}
+		notes += fmt.Sprintf("Former spouse award (%s, %s basis): $%.2f/yr, taxed to the former spouse.\n", input.Award.AwardType, basisName, award)
+	}
+
+	// Percentage and fraction awards share in COLAs; fixed awards only if the order says so
+	awardCOLA := input.Award.AwardType != "fixed" || input.Award.FixedAmountGetsCOLA
+	yearlyAward := make([]float64, input.ProjectionYears)
+	yearlyRetiree := make([]float64, input.ProjectionYears)
+	annuity := afterSurvivor
+	currentAward := award
+	for i := 0; i < input.ProjectionYears; i++ {
+		if i > 0 {
+			annuity *= 1 + input.COLARate
+			if awardCOLA {
+				currentAward *= 1 + input.COLARate
+			}
+		}
+		yearlyAward[i] = math.Min(currentAward, annuity)
+		yearlyRetiree[i] = annuity - yearlyAward[i]
+	}
+
+	return models.COAPCalculationResult{
+		GrossAnnuity:                 gross,
+		SurvivorReduction:            cost,
+		CurrentSpouseSurvivorCost:    cost - formerCost,
+		AnnuityAfterSurvivor:         afterSurvivor,
+		FormerSpouseAward:            award,
+		RetireeAnnuity:               afterSurvivor - award,
+		CurrentSpouseSurvivorAnnuity: currentSurvivor,
+		FormerSpouseSurvivorAnnuity:  formerSurvivor,
+		SurvivorCapApplied:           capApplied,
+		YearlyFormerSpouseAward:      yearlyAward,
+		YearlyRetireeAnnuity:         yearlyRetiree,
+		Notes:                        notes,
+	}
+}
@@ -9,9 +9,28 @@ import (
 // CalculateCSRS computes the CSRS or CSRS Offset annuity based on user input.
 func CalculateCSRS(input models.CSRSCalculationInput) models.CSRSCalculationResult {
 	var notes string
+	system := "CSRS"
+	if input.IsCSRSOffset {
+		system = "CSRSOffset"
+	}
+	serviceYears, eligibilityYears, isPartTime, prorationFactor, serviceNotes := resolveService(system, input.ServiceHistory, input.YearsOfService, input.IsPartTime, input.PartTimeProrationFactor)
+	notes += serviceNotes
+
+	// Military service credited without a deposit is removed at 62 if eligible for Social Security
+	removedAt62 := 0.0
+	if input.EligibleForSocialSecurity && len(input.ServiceHistory) > 0 {
+		removedAt62 = durationYears(CalculateServiceCredit(models.ServiceHistoryInput{RetirementSystem: system, Periods: input.ServiceHistory}).MilitaryRemovedAt62)
+		if removedAt62 > 0 && input.AgeAtRetirement >= 62 {
+			notes += fmt.Sprintf("%.2f years of military service without a deposit are not credited after 62 (eligible for Social Security).\n", removedAt62)
+			input.ServiceHistory = withoutUnpaidMilitary(input.ServiceHistory)
+			serviceYears, _, isPartTime, prorationFactor, _ = resolveService(system, input.ServiceHistory, input.YearsOfService, input.IsPartTime, input.PartTimeProrationFactor)
+		}
+	}
 
-	// Add sick leave to years of service (1 month = 1/12 year)
-	serviceYearsWithSick := input.YearsOfService + float64(input.UnusedSickLeaveMonths)/12.0
+	// Add sick leave in years/months/days and drop the odd days
+	sickLeave, sickNotes := sickLeaveCredit(input.UnusedSickLeaveHours, input.SickLeaveSchedule, input.SickLeaveHoursPerWeek, input.UnusedSickLeaveMonths)
+	notes += sickNotes
+	totalService, serviceYearsWithSick := computationWithSickLeave(system, input.ServiceHistory, serviceYears, sickLeave)
 
 	// Tiered multipliers
 	first5 := math.Min(serviceYearsWithSick, 5)
@@ -30,23 +49,40 @@ func CalculateCSRS(input models.CSRSCalculationInput) models.CSRSCalculationResu
 	// Part-time proration
 	proratedPension := baseAnnuity
 	prorationApplied := false
-	if input.IsPartTime && input.PartTimeProrationFactor > 0 && input.PartTimeProrationFactor < 1.0 {
-		proratedPension = baseAnnuity * input.PartTimeProrationFactor
+	if isPartTime && prorationFactor > 0 && prorationFactor < 1.0 {
+		proratedPension = baseAnnuity * prorationFactor
 		prorationApplied = true
-		notes += fmt.Sprintf("Part-time proration factor applied: %.2f\n", input.PartTimeProrationFactor)
+		notes += fmt.Sprintf("Part-time proration factor applied: %.2f\n", prorationFactor)
+	}
+
+	// Age reduction: 1/6 of 1% per month (2% per year) under 55 for voluntary retirement
+	earlyReduction := 0.0
+	ageMonths := input.AgeAtRetirement*12 + input.AgeAtRetirementMonths
+	if input.AgeAtRetirement > 0 && ageMonths < 55*12 && input.RetirementOption != "Disability" && input.RetirementOption != "SpecialProvision" {
+		monthsUnder := 55*12 - ageMonths
+		earlyReduction = proratedPension * math.Min(float64(monthsUnder)*0.02/12.0, 1.0)
+		notes += fmt.Sprintf("Early retirement reduction (2%% per year under 55, %d months): $%.2f\n", monthsUnder, earlyReduction)
 	}
+	reducedPension := proratedPension - earlyReduction
 
-	// Survivor benefit reduction (placeholder: adjust logic as needed)
-	survivorReduction := 0.0
-	if input.SurvivorBenefitElection == "max" {
-		survivorReduction = proratedPension * 0.10
-		notes += "Maximum survivor benefit reduction (10%) applied.\n"
-	} else if input.SurvivorBenefitElection == "partial" {
-		survivorReduction = proratedPension * 0.05
-		notes += "Partial survivor benefit reduction (5%) applied.\n"
+	// Survivor benefit reduction
+	survivorReduction, survivorAnnuity, survivorNote := getSurvivorReduction(system, input.SurvivorBenefitElection, reducedPension, input.SurvivorBaseAmount, beneficiaryYearsYounger(input.AgeAtRetirement, input.BeneficiaryAge))
+	if survivorReduction > 0 || input.SurvivorBenefitElection == "insurable interest" {
+		if input.SurvivorBenefitElection == "max" {
+			notes += "Maximum survivor benefit reduction applied. "
+		}
+		notes += survivorNote + "\n"
 	}
 
-	finalPension := proratedPension - survivorReduction
+	finalPension := reducedPension - survivorReduction
+
+	// Unpaid deposits and pre-3/1/91 redeposits permanently reduce the annuity
+	depositItems, depositReduction, depositNotes := civilianDeposits(system, input.ServiceHistory, input.AgeAtRetirement)
+	notes += depositNotes
+	finalPension -= depositReduction
+	if finalPension < 0 {
+		finalPension = 0
+	}
 
 	// CSRS Offset reduction (if applicable)
 	offsetReduction := 0.0
@@ -67,15 +103,38 @@ func CalculateCSRS(input models.CSRSCalculationInput) models.CSRSCalculationResu
 		}
 	}
 
+	// Retiring before 62, the annuity is recomputed without that service at 62
+	annualAt62 := finalPension
+	if removedAt62 > 0 && input.AgeAtRetirement < 62 {
+		at62 := input
+		at62.EligibleForSocialSecurity = false
+		at62.ServiceHistory = withoutUnpaidMilitary(input.ServiceHistory)
+		annualAt62 = CalculateCSRS(at62).AnnualPension
+		notes += fmt.Sprintf("%.2f years of military service without a deposit are removed at 62 (eligible for Social Security): annuity from 62 $%.2f\n", removedAt62, annualAt62)
+	}
+
+	taxFree, taxNotes := monthlyTaxFree(input.EmployeeContributions, finalPension/12.0, input.AgeAtRetirement, input.BeneficiaryAge, input.SurvivorBenefitElection)
+	notes += taxNotes
+
 	return models.CSRSCalculationResult{
 		AnnualPension:            finalPension,
+		AnnualPensionAt62:        annualAt62,
+		MilitaryRemovedAt62Years: removedAt62,
 		MonthlyPension:           finalPension / 12.0,
-		EarlyRetirementReduction: 0, // Placeholder: Add logic if needed
-		SickLeaveServiceCredit:   float64(input.UnusedSickLeaveMonths) / 12.0,
+		EarlyRetirementReduction: earlyReduction,
+		SickLeaveServiceCredit:   durationYears(sickLeave),
+		SickLeaveCredit:          sickLeave,
+		TotalComputationService:  totalService,
 		ProrationApplied:         prorationApplied,
 		ProratedPension:          proratedPension,
 		SurvivorBenefitReduction: survivorReduction,
+		SurvivorAnnuity:          survivorAnnuity,
 		OffsetReduction:          offsetReduction,
+		EligibilityServiceYears:  eligibilityYears,
+		ComputationServiceYears:  serviceYears,
+		DepositItems:             depositItems,
+		DepositReduction:         depositReduction,
+		MonthlyTaxFreeAmount:     taxFree,
 		Notes:                    notes,
 	}
 }
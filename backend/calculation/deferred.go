@@ -0,0 +1,164 @@
+package calculation
+
+import (
+	"ferex/backend/models"
+	"fmt"
+)
+
+// CalculateFERSDeferred computes a deferred or postponed FERS annuity and the annuity for each commencement age.
+func CalculateFERSDeferred(input models.FERSDeferredCalculationInput) models.FERSDeferredCalculationResult {
+	var notes string
+	fers := input.FERSInput
+
+	// Age at separation from birth date when available
+	separationAge := fers.AgeAtRetirement
+	if fers.BirthYear > 0 && !input.SeparationDate.IsZero() {
+		separationAge = input.SeparationDate.Year() - fers.BirthYear
+		if int(input.SeparationDate.Month()) < fers.BirthMonth {
+			separationAge--
+		}
+	}
+	fers.AgeAtRetirement = separationAge
+
+	_, eligibilityYears, _, _, _ := resolveService("FERS", fers.ServiceHistory, fers.YearsOfService, fers.IsPartTime, fers.PartTimeProrationFactor)
+	birthYear := fers.BirthYear
+	if birthYear == 0 {
+		birthYear = 1970
+		notes += "Birth year not provided: commencement ages assume the highest MRA (57).\n"
+	}
+	mraYears, mraMonths := GetMRA(birthYear)
+	mraAge := mraYears
+	if mraMonths > 0 {
+		mraAge++
+	}
+
+	eligibilityAt := func(option string, startAge int) models.FERSEligibilityResult {
+		return DetermineFERSEligibility(models.FERSEligibilityInput{
+			BirthYear:        fers.BirthYear,
+			BirthMonth:       fers.BirthMonth,
+			AgeAtRetirement:  separationAge,
+			YearsOfService:   eligibilityYears,
+			RetirementOption: option,
+			AnnuityStartAge:  startAge,
+		})
+	}
+
+	// MRA+10 at separation is a postponed retirement; otherwise the annuity is deferred
+	atSeparation := eligibilityAt("", 0)
+	if atSeparation.IsImmediate && atSeparation.RetirementType != "MRA+10" {
+		return immediateAtSeparation(fers, separationAge, notes+fmt.Sprintf("Eligible for an immediate unreduced annuity (%s) at separation.\n", atSeparation.RetirementType))
+	}
+	postponed := atSeparation.RetirementType == "MRA+10"
+	if postponed {
+		fers.RetirementOption = ""
+		notes += "Separated at MRA with 10+ years: postponed MRA+10 retirement.\n"
+	} else {
+		fers.RetirementOption = "Deferred"
+		fers.UnusedSickLeaveMonths = 0
+		fers.UnusedSickLeaveHours = 0
+		notes += "Deferred annuity: unused sick leave is not credited.\n"
+	}
+
+	earliest := 62
+	unreduced := 62
+	switch {
+	case eligibilityYears >= 30:
+		earliest, unreduced = mraAge, mraAge
+	case eligibilityYears >= 20:
+		earliest, unreduced = mraAge, 60
+	case eligibilityYears >= 10:
+		earliest = mraAge
+	}
+	if earliest < separationAge {
+		earliest = separationAge
+	}
+	if unreduced < earliest {
+		unreduced = earliest
+	}
+	if eligibilityYears < 5 {
+		notes += "Fewer than 5 years of creditable service: no deferred annuity is payable.\n"
+	}
+
+	// Annuity for every commencement age from the earliest to 62
+	var table []models.DeferredAnnuityOption
+	for age := earliest; age <= max(62, earliest) && eligibilityYears >= 5; age++ {
+		option := fers
+		option.AnnuityStartAge = age
+		r := CalculateFERSPension(option)
+		table = append(table, models.DeferredAnnuityOption{
+			CommencementAge:  age,
+			ReductionPercent: eligibilityAt(fers.RetirementOption, age).ReductionPercent,
+			AnnualAnnuity:    r.AnnualPension,
+			RetirementType:   r.RetirementType,
+		})
+	}
+
+	commencementAge := input.CommencementAge
+	if commencementAge < earliest {
+		if commencementAge != 0 {
+			notes += fmt.Sprintf("Annuity cannot begin before age %d; earliest age used.\n", earliest)
+		}
+		commencementAge = earliest
+	}
+	chosen := fers
+	chosen.AnnuityStartAge = commencementAge
+	chosenResult := CalculateFERSPension(chosen)
+	reduction := eligibilityAt(fers.RetirementOption, commencementAge).ReductionPercent
+
+	notes += "High-3 is frozen at separation with no inflation adjustment.\n"
+	if !chosenResult.SRSPayable {
+		notes += "FERS Annuity Supplement (SRS) is not payable on a deferred or postponed annuity.\n"
+	}
+	if postponed {
+		notes += "FEHB can be reinstated when the annuity begins if enrolled for the 5 years before separation.\n"
+	} else if !chosenResult.SRSPayable {
+		notes += "FEHB coverage cannot be continued into a deferred annuity.\n"
+	}
+	if eligibilityYears >= 20 && eligibilityYears < 30 {
+		notes += "MRA+10 reduction is waived if the annuity begins at 60 or later (20+ years of service).\n"
+	}
+
+	return models.FERSDeferredCalculationResult{
+		AgeAtSeparation:          separationAge,
+		EarliestCommencementAge:  earliest,
+		UnreducedCommencementAge: unreduced,
+		CommencementAge:          commencementAge,
+		AnnualAnnuity:            chosenResult.AnnualPension,
+		MonthlyAnnuity:           chosenResult.MonthlyPension,
+		ReductionPercent:         reduction,
+		RetirementType:           chosenResult.RetirementType,
+		SRSPayable:               chosenResult.SRSPayable,
+		FEHBContinues:            postponed || chosenResult.SRSPayable,
+		CommencementTable:        table,
+		Notes:                    notes + chosenResult.Notes,
+	}
+}
+
+// immediateAtSeparation returns the immediate annuity for a separation that already qualifies for one, with
+// sick leave credited and the annuity beginning at separation.
+func immediateAtSeparation(fers models.FERSCalculationInput, separationAge int, notes string) models.FERSDeferredCalculationResult {
+	fers.RetirementOption = ""
+	fers.AnnuityStartAge = 0
+	r := CalculateFERSPension(fers)
+	if !r.SRSPayable && separationAge < 62 {
+		notes += "FERS Annuity Supplement (SRS) is not payable.\n"
+	}
+	notes += "FEHB continues into retirement if enrolled for the 5 years before separation.\n"
+	return models.FERSDeferredCalculationResult{
+		AgeAtSeparation:          separationAge,
+		EarliestCommencementAge:  separationAge,
+		UnreducedCommencementAge: separationAge,
+		CommencementAge:          separationAge,
+		AnnualAnnuity:            r.AnnualPension,
+		MonthlyAnnuity:           r.MonthlyPension,
+		RetirementType:           r.RetirementType,
+		SRSPayable:               r.SRSPayable,
+		FEHBContinues:            true,
+		CommencementTable: []models.DeferredAnnuityOption{{
+			CommencementAge: separationAge,
+			AnnualAnnuity:   r.AnnualPension,
+			RetirementType:  r.RetirementType,
+		}},
+		Notes: notes + r.Notes,
+	}
+}
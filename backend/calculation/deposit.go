@@ -0,0 +1,137 @@
+package calculation
+
+import (
+	"ferex/backend/models"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Approximate present value factors (monthly annuity) by age, used for the actuarial redeposit reduction and the
+// Alternative Form of Annuity. These are estimates, not OPM's published factors, so results that use them are
+// marked as estimates.
+var redepositPresentValueFactors = []struct {
+	Age    int
+	Factor float64
+}{
+	{50, 292.0}, {55, 268.0}, {60, 240.0}, {62, 228.0}, {65, 209.0}, {70, 176.0}, {75, 143.0}, {80, 112.0},
+}
+
+// presentValueFactor interpolates the present value factor for an age at retirement.
+func presentValueFactor(age int) float64 {
+	table := redepositPresentValueFactors
+	if age <= table[0].Age {
+		return table[0].Factor
+	}
+	for i := 1; i < len(table); i++ {
+		if age <= table[i].Age {
+			lo, hi := table[i-1], table[i]
+			return lo.Factor + (hi.Factor-lo.Factor)*float64(age-lo.Age)/float64(hi.Age-lo.Age)
+		}
+	}
+	return table[len(table)-1].Factor
+}
+
+// civilianDepositRate returns the deposit rate on basic pay for non-deduction service.
+func civilianDepositRate(system string, day time.Time) float64 {
+	if system == "FERS" {
+		return 0.013
+	}
+	switch {
+	case day.Before(time.Date(1942, time.July, 1, 0, 0, 0, 0, time.UTC)):
+		return 0.035
+	case day.Before(time.Date(1948, time.July, 1, 0, 0, 0, 0, time.UTC)):
+		return 0.05
+	case day.Before(time.Date(1956, time.November, 1, 0, 0, 0, 0, time.UTC)):
+		return 0.06
+	case day.Before(time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC)):
+		return 0.065
+	default:
+		return 0.07
+	}
+}
+
+// civilianDeposits itemizes deposits for non-deduction service and redeposits for refunded service,
+// with interest to the end of the service history and the CSRS annuity reduction for anything unpaid.
+func civilianDeposits(system string, periods []models.ServicePeriod, ageAtRetirement int) (items []models.DepositItem, reduction float64, notes string) {
+	if len(periods) == 0 {
+		return nil, 0, ""
+	}
+	sorted := make([]models.ServicePeriod, len(periods))
+	copy(sorted, periods)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].StartDate.Before(sorted[j].StartDate) })
+	retirement := sorted[0].EndDate
+	for _, p := range sorted {
+		if p.EndDate.After(retirement) {
+			retirement = p.EndDate
+		}
+	}
+	retirement = retirement.AddDate(0, 0, 1)
+	if ageAtRetirement == 0 {
+		ageAtRetirement = 55
+	}
+	isFERS := system == "FERS"
+
+	for _, p := range sorted {
+		item := models.DepositItem{StartDate: p.StartDate, EndDate: p.EndDate, Paid: p.DepositPaid}
+		var interestFrom time.Time
+		switch {
+		case p.ServiceType == "military":
+			continue
+		case p.Refunded:
+			item.Kind = "redeposit"
+			item.AmountDue = p.RefundAmount
+			interestFrom = p.RefundDate
+			if interestFrom.IsZero() {
+				interestFrom = p.EndDate
+			}
+		case p.ServiceType == "temporary":
+			item.Kind = "deposit"
+			if isFERS && !p.StartDate.Before(fersDepositCutoff) {
+				item.Treatment = "not creditable"
+				items = append(items, item)
+				continue
+			}
+			item.AmountDue = p.BasicPay * civilianDepositRate(system, p.StartDate)
+			// Interest runs from the middle of the period
+			interestFrom = p.StartDate.Add(p.EndDate.Sub(p.StartDate) / 2)
+		default:
+			continue
+		}
+		if item.AmountDue == 0 {
+			notes += fmt.Sprintf("No pay or refund amount given for the %s on %s to %s; amount due not computed.\n", item.Kind, p.StartDate.Format("2006-01-02"), p.EndDate.Format("2006-01-02"))
+		}
+		if interestFrom.Before(retirement) {
+			// CSRS deposits for service before 10/1/1982 never move to the variable rate
+			preOctober1982 := !isFERS && item.Kind == "deposit" && p.EndDate.Before(csrsDepositCutoff)
+			item.Interest = accrueDepositInterest(item.AmountDue, interestFrom, retirement, preOctober1982, opmDepositInterestRates[2025])
+		}
+		owed := item.AmountDue + item.Interest
+
+		switch {
+		case p.DepositPaid:
+			item.Treatment = "credited"
+		case isFERS:
+			item.Treatment = "not creditable"
+		case item.Kind == "deposit" && p.EndDate.Before(csrsDepositCutoff):
+			item.Treatment = "10% reduction"
+			item.AnnuityReduction = owed * 0.10
+		case item.Kind == "redeposit" && p.EndDate.Before(csrsRedepositCutoff):
+			item.Treatment = "actuarial reduction"
+			item.AnnuityReduction = owed / presentValueFactor(ageAtRetirement) * 12
+			item.IsEstimate = true
+		default:
+			item.Treatment = "eligibility only"
+		}
+		if item.IsEstimate {
+			notes += fmt.Sprintf("Unpaid %s of $%.2f for %s to %s: annuity reduced an estimated $%.2f/yr (%s with an approximate present value factor, not OPM's published factor).\n", item.Kind, owed, p.StartDate.Format("2006-01-02"), p.EndDate.Format("2006-01-02"), item.AnnuityReduction, item.Treatment)
+		} else if item.AnnuityReduction > 0 {
+			notes += fmt.Sprintf("Unpaid %s of $%.2f for %s to %s: annuity reduced $%.2f/yr (%s).\n", item.Kind, owed, p.StartDate.Format("2006-01-02"), p.EndDate.Format("2006-01-02"), item.AnnuityReduction, item.Treatment)
+		} else if !p.DepositPaid && owed > 0 {
+			notes += fmt.Sprintf("Paying the %s of $%.2f for %s to %s would add the period to the computation.\n", item.Kind, owed, p.StartDate.Format("2006-01-02"), p.EndDate.Format("2006-01-02"))
+		}
+		reduction += item.AnnuityReduction
+		items = append(items, item)
+	}
+	return items, reduction, notes
+}
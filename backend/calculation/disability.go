@@ -0,0 +1,120 @@
+package calculation
+
+import (
+	"ferex/backend/models"
+	"fmt"
+)
+
+// CalculateFERSDisability projects a FERS disability annuity year by year, including the age-62 recomputation.
+func CalculateFERSDisability(input models.FERSDisabilityCalculationInput) models.FERSDisabilityCalculationResult {
+	var notes string
+	years := input.YearsToProject
+	if years <= 0 {
+		years = 90 - input.AgeAtDisability
+		if years < 1 {
+			years = 1
+		}
+	}
+	// FERS survivor costs are a flat share of the annuity, so take the rate per dollar
+	survivorRate, _, survivorNote := getSurvivorReduction("FERS", input.SurvivorBenefitElection, 1, 0, beneficiaryYearsYounger(input.AgeAtDisability, input.BeneficiaryAge))
+	diet := getFERSCOLA(input.COLARate*100) / 100.0
+
+	earnedMultiplier := 0.01
+	if input.AgeAtDisability >= 62 && input.YearsOfService >= 20 {
+		earnedMultiplier = 0.011
+	}
+	earned := input.High3Salary * input.YearsOfService * earnedMultiplier
+
+	// Time on the disability rolls is credited as service at 62
+	recomputedService := input.YearsOfService
+	if input.AgeAtDisability < 62 {
+		recomputedService += float64(62 - input.AgeAtDisability)
+	}
+	recomputedMultiplier := 0.01
+	if recomputedService >= 20 {
+		recomputedMultiplier = 0.011
+	}
+
+	var series []models.DisabilityAnnuityYear
+	colaFactor := 1.0
+	recomputedHigh3 := 0.0
+	recomputed := 0.0
+	firstYearReduction := 0.0
+	for i := 0; i < years; i++ {
+		age := input.AgeAtDisability + i
+		cola := 0.0
+		if i > 0 {
+			cola = diet
+			colaFactor *= 1 + cola
+		}
+
+		var gross, offset float64
+		phase := ""
+		switch {
+		case input.AgeAtDisability >= 62:
+			gross = earned * colaFactor
+			phase = "Earned"
+		case age >= 62:
+			if recomputedHigh3 == 0 {
+				// High-3 is inflated by the COLAs paid while on the disability rolls
+				recomputedHigh3 = input.High3Salary * colaFactor
+				recomputed = recomputedHigh3 * recomputedService * recomputedMultiplier
+				colaFactor = 1.0
+				notes += fmt.Sprintf("Recomputed at 62: High-3 $%.2f, %.2f years of service, annuity $%.2f.\n", recomputedHigh3, recomputedService, recomputed)
+			}
+			gross = recomputed * colaFactor
+			phase = "Recomputed"
+		case i == 0:
+			offset = input.SSDisabilityBenefit
+			gross = input.High3Salary*0.6 - offset
+			phase = "60%"
+		default:
+			offset = input.SSDisabilityBenefit * 0.6
+			gross = input.High3Salary*0.4*colaFactor - offset
+			phase = "40%"
+		}
+		// The earned annuity is paid instead if it is higher than the disability formula
+		if phase == "60%" || phase == "40%" {
+			if earnedNow := earned * colaFactor; earnedNow > gross {
+				gross = earnedNow
+				offset = 0
+				phase = "Earned"
+			}
+		}
+		if gross < 0 {
+			gross = 0
+		}
+		reduction := gross * survivorRate
+		if i == 0 {
+			firstYearReduction = reduction
+		}
+		series = append(series, models.DisabilityAnnuityYear{
+			Age:      age,
+			Phase:    phase,
+			Annuity:  gross - reduction,
+			SSOffset: offset,
+			COLA:     cola,
+		})
+	}
+
+	if input.SSDisabilityBenefit > 0 && input.AgeAtDisability < 62 {
+		notes += "Social Security disability offset applied: 100% in the first 12 months, 60% thereafter until 62.\n"
+	}
+	if survivorRate > 0 || input.SurvivorBenefitElection == "insurable interest" {
+		notes += survivorNote + ".\n"
+	}
+	taxFree, taxNotes := monthlyTaxFree(input.EmployeeContributions, series[0].Annuity/12.0, input.AgeAtDisability, input.BeneficiaryAge, input.SurvivorBenefitElection)
+	notes += taxNotes
+
+	return models.FERSDisabilityCalculationResult{
+		YearlyAnnuity:            series,
+		FirstYearAnnuity:         series[0].Annuity,
+		EarnedAnnuity:            earned,
+		RecomputedHigh3:          recomputedHigh3,
+		RecomputedServiceYears:   recomputedService,
+		RecomputedAnnuityAt62:    recomputed,
+		SurvivorBenefitReduction: firstYearReduction,
+		MonthlyTaxFreeAmount:     taxFree,
+		Notes:                    notes,
+	}
+}
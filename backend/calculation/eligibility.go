@@ -0,0 +1,139 @@
+package calculation
+
+import (
+	"ferex/backend/models"
+	"fmt"
+	"math"
+)
+
+// GetMRA returns the FERS Minimum Retirement Age for a birth year as years and months.
+func GetMRA(birthYear int) (years, months int) {
+	switch {
+	case birthYear < 1948:
+		return 55, 0
+	case birthYear <= 1952:
+		return 55, (birthYear - 1947) * 2
+	case birthYear <= 1964:
+		return 56, 0
+	case birthYear <= 1969:
+		return 56, (birthYear - 1964) * 2
+	default:
+		return 57, 0
+	}
+}
+
+// mraPlus10Reduction returns the 5%-per-year (5/12 of 1% per month) reduction for starting an annuity under 62.
+func mraPlus10Reduction(ageMonths int) float64 {
+	monthsUnder := 62*12 - ageMonths
+	if monthsUnder <= 0 {
+		return 0
+	}
+	return float64(monthsUnder) * 0.05 / 12.0
+}
+
+// DetermineFERSEligibility classifies a FERS retirement and reports the reduction, multiplier and SRS rules that follow.
+func DetermineFERSEligibility(input models.FERSEligibilityInput) models.FERSEligibilityResult {
+	var notes string
+	result := models.FERSEligibilityResult{}
+	// Without a birth year the MRA is unknown; MRA-based types are tested against the highest MRA of 57
+	mra := 57 * 12
+	if input.BirthYear > 0 {
+		result.MRAYears, result.MRAMonths = GetMRA(input.BirthYear)
+		mra = result.MRAYears*12 + result.MRAMonths
+	} else {
+		notes += "Birth year not provided: MRA cannot be determined, so MRA-based eligibility is tested at the highest MRA (57).\n"
+	}
+	mraYears, mraMonths := mra/12, mra%12
+
+	// Age at separation in months
+	ageMonths := input.AgeAtRetirement * 12
+	if input.RetirementYear > 0 && input.BirthYear > 0 {
+		ageMonths = (input.RetirementYear-input.BirthYear)*12 + max(input.RetirementMonth, 1) - max(input.BirthMonth, 1)
+	}
+	service := input.YearsOfService
+
+	startMonths := ageMonths
+	if input.AnnuityStartAge*12 > ageMonths {
+		startMonths = input.AnnuityStartAge * 12
+	}
+
+	switch {
+	case input.RetirementOption == "Disability":
+		if service >= 1.5 {
+			result.RetirementType = "Disability"
+			result.IsEligible = true
+			result.IsImmediate = true
+			notes += "Disability retirement: no age reduction, SRS not payable.\n"
+		}
+	case (input.RetirementOption == "VERA" || input.RetirementOption == "DSR") &&
+		((ageMonths >= 50*12 && service >= 20) || service >= 25):
+		result.RetirementType = input.RetirementOption
+		result.IsEligible = true
+		result.IsImmediate = true
+		result.SRSPayable = ageMonths < 62*12
+		result.SRSStartsAtMRA = ageMonths < mra
+		notes += fmt.Sprintf("%s retirement: no age reduction.\n", input.RetirementOption)
+		if result.SRSStartsAtMRA {
+			notes += fmt.Sprintf("SRS payable from MRA (%d years %d months).\n", mraYears, mraMonths)
+		}
+	case input.RetirementOption != "Deferred" && ageMonths >= 62*12 && service >= 5:
+		result.RetirementType = "62+5"
+		result.IsEligible = true
+		result.IsImmediate = true
+	case input.RetirementOption != "Deferred" && ageMonths >= 60*12 && service >= 20:
+		result.RetirementType = "60+20"
+		result.IsEligible = true
+		result.IsImmediate = true
+		result.SRSPayable = true
+	case input.RetirementOption != "Deferred" && ageMonths >= mra && service >= 30:
+		result.RetirementType = "MRA+30"
+		result.IsEligible = true
+		result.IsImmediate = true
+		result.SRSPayable = true
+	case input.RetirementOption != "Deferred" && ageMonths >= mra && service >= 10:
+		result.IsEligible = true
+		if startMonths > ageMonths {
+			result.RetirementType = "MRA+10 Postponed"
+		} else {
+			result.RetirementType = "MRA+10"
+			result.IsImmediate = true
+		}
+		if service >= 20 && startMonths >= 60*12 {
+			notes += "MRA+10 reduction waived: 20+ years of service and annuity starts at 60 or later.\n"
+		} else {
+			result.ReductionPercent = mraPlus10Reduction(startMonths)
+		}
+	case service >= 5:
+		result.RetirementType = "Deferred"
+		result.IsEligible = true
+		if startMonths < mra {
+			startMonths = 62 * 12
+		}
+		switch {
+		case startMonths >= 62*12:
+		case service >= 30 && startMonths >= mra:
+		case service >= 20 && startMonths >= 60*12:
+		case service >= 10 && startMonths >= mra:
+			result.ReductionPercent = mraPlus10Reduction(startMonths)
+		default:
+			startMonths = 62 * 12
+		}
+		notes += fmt.Sprintf("Deferred annuity commencing at age %d; SRS not payable.\n", startMonths/12)
+	}
+
+	if !result.IsEligible {
+		result.RetirementType = "Ineligible"
+		notes += "Not eligible for a FERS annuity with the service and age provided.\n"
+	}
+
+	// 1.1% multiplier: age 62 or older at separation with 20+ years of service (a later start does not qualify)
+	if result.IsEligible && result.RetirementType != "Disability" && ageMonths >= 62*12 && service >= 20 {
+		result.AppliesHigherFactor = true
+	}
+	if result.ReductionPercent > 0 {
+		result.ReductionPercent = math.Min(result.ReductionPercent, 1.0)
+		notes += fmt.Sprintf("%s age reduction: %.2f%%.\n", result.RetirementType, result.ReductionPercent*100)
+	}
+	result.Notes = notes
+	return result
+}
@@ -8,66 +8,96 @@ import (
 // CalculateFERSPension computes the FERS annuity based on user input.
 func CalculateFERSPension(input models.FERSCalculationInput) models.FERSCalculationResult {
 	var notes string
-	serviceYears := input.YearsOfService
+	serviceYears, eligibilityYears, isPartTime, prorationFactor, serviceNotes := resolveService("FERS", input.ServiceHistory, input.YearsOfService, input.IsPartTime, input.PartTimeProrationFactor)
+	notes += serviceNotes
+	depositItems, _, depositNotes := civilianDeposits("FERS", input.ServiceHistory, input.AgeAtRetirement)
+	notes += depositNotes
 
-	// Add sick leave (convert months to years)
-	sickLeaveYears := float64(input.UnusedSickLeaveMonths) / 12.0
-	serviceYearsWithSick := serviceYears + sickLeaveYears
+	// Add sick leave in years/months/days and drop the odd days
+	sickLeave, sickNotes := sickLeaveCredit(input.UnusedSickLeaveHours, input.SickLeaveSchedule, input.SickLeaveHoursPerWeek, input.UnusedSickLeaveMonths)
+	notes += sickNotes
+	sickLeaveYears := durationYears(sickLeave)
+	totalService, serviceYearsWithSick := computationWithSickLeave("FERS", input.ServiceHistory, serviceYears, sickLeave)
 
-	// Determine multiplier
+	// Classify the retirement to determine reductions, multiplier and SRS eligibility
+	eligibility := DetermineFERSEligibility(models.FERSEligibilityInput{
+		BirthYear:        input.BirthYear,
+		BirthMonth:       input.BirthMonth,
+		AgeAtRetirement:  input.AgeAtRetirement,
+		RetirementYear:   input.RetirementYear,
+		RetirementMonth:  input.RetirementMonth,
+		YearsOfService:   eligibilityYears,
+		RetirementOption: input.RetirementOption,
+		AnnuityStartAge:  input.AnnuityStartAge,
+	})
+	notes += fmt.Sprintf("Retirement type: %s\n", eligibility.RetirementType)
+	notes += eligibility.Notes
+	if !eligibility.IsEligible {
+		return models.FERSCalculationResult{
+			SickLeaveServiceCredit:  sickLeaveYears,
+			EligibilityServiceYears: eligibilityYears,
+			ComputationServiceYears: serviceYears,
+			RetirementType:          eligibility.RetirementType,
+			Notes:                   notes,
+		}
+	}
+
+	// Determine multiplier (the classification alone decides the 1.1%)
 	multiplier := 0.01
-	if input.IsAge62With20Years {
+	if eligibility.AppliesHigherFactor {
 		multiplier = 0.011
 	}
 
 	// Calculate base annuity
 	baseAnnuity := input.High3Salary * serviceYearsWithSick * multiplier
-	fmt.Printf("DEBUG: baseAnnuity=%.2f, IsPartTime=%v, PartTimeProrationFactor=%.2f\n", baseAnnuity, input.IsPartTime, input.PartTimeProrationFactor)
 
-	// Early retirement reduction (FERS MRA+10: 5% per year under 62)
-	earlyReduction := 0.0
-	if input.AgeAtRetirement < 62 {
-		yearsUnder := 62 - input.AgeAtRetirement
-		earlyReduction = baseAnnuity * 0.05 * float64(yearsUnder)
-		baseAnnuity -= earlyReduction
-		if earlyReduction > 0 {
-			notes += fmt.Sprintf("Early retirement reduction applied: %.2f\n", earlyReduction)
-		}
+	// Early retirement reduction (MRA+10 and reduced deferred annuities: 5/12 of 1% per month under 62)
+	earlyReduction := baseAnnuity * eligibility.ReductionPercent
+	baseAnnuity -= earlyReduction
+	if earlyReduction > 0 {
+		notes += fmt.Sprintf("Early retirement reduction applied: %.2f\n", earlyReduction)
 	}
 
 	// Part-time proration
 	proratedPension := baseAnnuity
 	prorationApplied := false
-	if input.IsPartTime && input.PartTimeProrationFactor > 0 && input.PartTimeProrationFactor < 1.0 {
-		proratedPension = baseAnnuity * input.PartTimeProrationFactor
+	if isPartTime && prorationFactor > 0 && prorationFactor < 1.0 {
+		proratedPension = baseAnnuity * prorationFactor
 		prorationApplied = true
-		notes += fmt.Sprintf("Part-time proration factor applied: %.2f\n", input.PartTimeProrationFactor)
+		notes += fmt.Sprintf("Part-time proration factor applied: %.2f\n", prorationFactor)
 	}
-	fmt.Printf("DEBUG: after proration, proratedPension=%.2f\n", proratedPension)
 
 	// Survivor benefit reduction (if any)
-	survivorReduction := 0.0
-	switch input.SurvivorBenefitElection {
-	case "max":
-		survivorReduction = proratedPension * 0.10 // 10% reduction for max survivor
+	survivorReduction, survivorAnnuity, survivorNote := getSurvivorReduction("FERS", input.SurvivorBenefitElection, proratedPension, 0, beneficiaryYearsYounger(input.AgeAtRetirement, input.BeneficiaryAge))
+	if survivorReduction > 0 || input.SurvivorBenefitElection == "insurable interest" {
 		proratedPension -= survivorReduction
-		notes += "Maximum survivor benefit elected (10% reduction).\n"
-	case "partial":
-		survivorReduction = proratedPension * 0.05 // 5% reduction for partial survivor
-		proratedPension -= survivorReduction
-		notes += "Partial survivor benefit elected (5% reduction).\n"
-	default:
-		// No reduction for "none", empty, or any other value
+		notes += survivorNote + "\n"
+	}
+
+	startAge := input.AgeAtRetirement
+	if input.AnnuityStartAge > startAge {
+		startAge = input.AnnuityStartAge
 	}
+	taxFree, taxNotes := monthlyTaxFree(input.EmployeeContributions, proratedPension/12.0, startAge, input.BeneficiaryAge, input.SurvivorBenefitElection)
+	notes += taxNotes
 
 	return models.FERSCalculationResult{
 		AnnualPension:            proratedPension,
 		MonthlyPension:           proratedPension / 12.0,
 		EarlyRetirementReduction: earlyReduction,
 		SickLeaveServiceCredit:   sickLeaveYears,
+		SickLeaveCredit:          sickLeave,
+		TotalComputationService:  totalService,
 		ProrationApplied:         prorationApplied,
 		ProratedPension:          proratedPension,
 		SurvivorBenefitReduction: survivorReduction,
+		SurvivorAnnuity:          survivorAnnuity,
+		EligibilityServiceYears:  eligibilityYears,
+		ComputationServiceYears:  serviceYears,
+		DepositItems:             depositItems,
+		MonthlyTaxFreeAmount:     taxFree,
+		RetirementType:           eligibility.RetirementType,
+		SRSPayable:               eligibility.SRSPayable,
 		Notes:                    notes,
 	}
 }
@@ -0,0 +1,119 @@
+package calculation
+
+import (
+	"ferex/backend/models"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// annualRate returns the total annual basic pay for a rate entry.
+func annualRate(r models.SalaryRate) float64 {
+	if r.AnnualRate > 0 {
+		return r.AnnualRate
+	}
+	return r.BasePay * (1 + r.LocalityPercent)
+}
+
+// projectRaises appends a January raise for each year after the last entry up to the retirement date.
+func projectRaises(history []models.SalaryRate, retirement time.Time, raise float64) []models.SalaryRate {
+	if raise == 0 || len(history) == 0 {
+		return nil
+	}
+	last := history[len(history)-1]
+	rate := annualRate(last)
+	var projected []models.SalaryRate
+	for year := last.EffectiveDate.Year() + 1; ; year++ {
+		effective := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		if !effective.Before(retirement) {
+			break
+		}
+		rate *= 1 + raise
+		projected = append(projected, models.SalaryRate{
+			EffectiveDate: effective,
+			Grade:         last.Grade,
+			Step:          last.Step,
+			AnnualRate:    rate,
+			Reason:        "projected raise",
+		})
+	}
+	return projected
+}
+
+// CalculateHigh3 finds the highest average basic pay over any 36 consecutive months, day by day.
+func CalculateHigh3(input models.High3CalculationInput) models.High3CalculationResult {
+	var notes string
+	if len(input.SalaryHistory) == 0 || input.RetirementDate.IsZero() {
+		return models.High3CalculationResult{Notes: "Salary history and retirement date are required.\n"}
+	}
+
+	history := make([]models.SalaryRate, len(input.SalaryHistory))
+	copy(history, input.SalaryHistory)
+	sort.Slice(history, func(i, j int) bool { return history[i].EffectiveDate.Before(history[j].EffectiveDate) })
+	projected := projectRaises(history, input.RetirementDate, input.ProjectedRaisePercent)
+	if len(projected) > 0 {
+		notes += fmt.Sprintf("%d projected January raises of %.2f%% applied.\n", len(projected), input.ProjectedRaisePercent*100)
+	}
+	rates := append(history, projected...)
+
+	// Daily pay from the first rate to the day before separation
+	first := rates[0].EffectiveDate
+	days := int(input.RetirementDate.Sub(first).Hours() / 24)
+	if days <= 0 {
+		return models.High3CalculationResult{Notes: "Retirement date must be after the first salary entry.\n"}
+	}
+	prefix := make([]float64, days+1)
+	next := 0
+	current := 0.0
+	for d := 0; d < days; d++ {
+		day := first.AddDate(0, 0, d)
+		for next < len(rates) && !rates[next].EffectiveDate.After(day) {
+			current = annualRate(rates[next])
+			next++
+		}
+		prefix[d+1] = prefix[d] + current
+	}
+
+	// Slide a three-year window (start through the day before the third anniversary) across the history
+	bestStart, bestEnd := 0, days
+	best := prefix[days] / float64(days)
+	if first.AddDate(3, 0, 0).After(input.RetirementDate) {
+		notes += "Less than three years of pay history; High-3 averages all pay provided.\n"
+	} else {
+		best = 0
+		for start := 0; ; start++ {
+			startDate := first.AddDate(0, 0, start)
+			end := int(startDate.AddDate(3, 0, 0).Sub(first).Hours() / 24)
+			if end > days {
+				break
+			}
+			avg := (prefix[end] - prefix[start]) / float64(end-start)
+			if avg > best {
+				best, bestStart, bestEnd = avg, start, end
+			}
+		}
+	}
+
+	windowStart := first.AddDate(0, 0, bestStart)
+	windowEnd := first.AddDate(0, 0, bestEnd-1)
+	var windowRates []models.SalaryRate
+	for i, r := range rates {
+		if r.EffectiveDate.After(windowEnd) {
+			break
+		}
+		if i+1 < len(rates) && !rates[i+1].EffectiveDate.After(windowStart) {
+			continue
+		}
+		windowRates = append(windowRates, r)
+	}
+	notes += fmt.Sprintf("High-3 window: %s to %s, average $%.2f.\n", windowStart.Format("2006-01-02"), windowEnd.Format("2006-01-02"), best)
+
+	return models.High3CalculationResult{
+		High3Salary:    best,
+		WindowStart:    windowStart,
+		WindowEnd:      windowEnd,
+		WindowRates:    windowRates,
+		ProjectedRates: projected,
+		Notes:          notes,
+	}
+}
@@ -0,0 +1,174 @@
+package calculation
+
+import (
+	"ferex/backend/models"
+	"fmt"
+)
+
+// First birth year subject to deemed filing (applying for either retirement or spousal benefits applies for both)
+const deemedFilingBirthYear = 1954
+
+// Survivor benefit at 60 as a share of the deceased's benefit; it rises evenly to 100% at survivor FRA
+const survivorBenefitAt60 = 0.715
+
+// RIB-LIM: the survivor of an early claimer is limited to the larger of the deceased's benefit or 82.5% of the PIA
+const ribLimFloor = 0.825
+
+// spousalFactor returns the spousal benefit as a share of the full spousal amount when it starts at startMonths:
+// 25/36 of 1% per month for the first 36 months before FRA and 5/12 of 1% beyond. There are no delayed credits.
+func spousalFactor(startMonths, fraMonths int) float64 {
+	if startMonths >= fraMonths {
+		return 1.0
+	}
+	early := fraMonths - startMonths
+	return 1.0 - float64(min(early, 36))*25.0/3600.0 - float64(max(early-36, 0))*5.0/1200.0
+}
+
+// survivorFRA returns the survivor full retirement age in months; the schedule runs two birth years behind retirement FRA.
+func survivorFRA(birthYear int) int {
+	years, months := FullRetirementAge(birthYear - 2)
+	return years*12 + months
+}
+
+// survivorFactor returns the survivor benefit as a share of the deceased's benefit when it starts at startMonths.
+func survivorFactor(startMonths, birthYear int) float64 {
+	fra := survivorFRA(birthYear)
+	if startMonths >= fra {
+		return 1.0
+	}
+	early := fra - max(startMonths, 60*12)
+	return 1.0 - (1.0-survivorBenefitAt60)*float64(early)/float64(fra-60*12)
+}
+
+// memberClaimMonths returns a spouse's claiming age in months; 0 claims at FRA.
+func memberClaimMonths(m models.HouseholdMember) int {
+	if m.ClaimAge == 0 {
+		years, months := FullRetirementAge(m.BirthYear)
+		return years*12 + months
+	}
+	return m.ClaimAge*12 + m.ClaimAgeMonths
+}
+
+// householdMemberBenefits computes one spouse's own, spousal, and survivor benefits. Ages are in months of self's age.
+func householdMemberBenefits(self, other models.HouseholdMember) (models.HouseholdMemberResult, string) {
+	var notes string
+	fraYears, fraMonths := FullRetirementAge(self.BirthYear)
+	fra := fraYears*12 + fraMonths
+	claim := memberClaimMonths(self)
+	result := models.HouseholdMemberResult{
+		ClaimAgeMonths: claim,
+		OwnBenefit:     self.PIA * claimingFactor(claim, fra),
+	}
+	// Self's age is the other's age plus the difference in birth years
+	offset := (other.BirthYear - self.BirthYear) * 12
+	otherFRAYears, otherFRAMonths := FullRetirementAge(other.BirthYear)
+	otherFRA := otherFRAYears*12 + otherFRAMonths
+	otherClaim := memberClaimMonths(other)
+
+	// Spousal benefit: the excess of half the other's PIA over own PIA, once the other has filed
+	excess := max(0.5*other.PIA-self.PIA, 0)
+	if self.BirthYear < deemedFilingBirthYear && claim > fra && other.PIA > 0 {
+		// Restricted application: half the other's PIA alone from FRA while the own benefit earns delayed credits
+		result.SpousalStartAgeMonths = max(fra, otherClaim+offset)
+		if result.SpousalStartAgeMonths < claim {
+			result.RestrictedBenefit = 0.5 * other.PIA
+			notes += fmt.Sprintf("Restricted application: $%.2f spousal-only benefit until own claim.\n", result.RestrictedBenefit)
+		}
+		result.SpousalBenefit = excess
+	} else if excess > 0 {
+		// Deemed filing: the spousal benefit starts with the own benefit, or when the other files if later
+		result.SpousalStartAgeMonths = max(claim, otherClaim+offset)
+		result.SpousalBenefit = excess * spousalFactor(result.SpousalStartAgeMonths, fra)
+		if result.SpousalStartAgeMonths > claim {
+			notes += fmt.Sprintf("Spousal benefit starts at %d years %d months, when the spouse files.\n", result.SpousalStartAgeMonths/12, result.SpousalStartAgeMonths%12)
+		}
+	}
+
+	// Survivor benefit on the other's record, starting at the death (no earlier than 60)
+	otherBenefit := other.PIA * claimingFactor(otherClaim, otherFRA)
+	otherClaimed := true
+	if other.DeathAge > 0 {
+		death := (other.DeathAge + 1) * 12
+		result.SurvivorStartAgeMonths = max(death+offset, 60*12)
+		otherClaimed = otherClaim < death
+		if !otherClaimed {
+			// Died before filing: the PIA plus any delayed credits earned by the death
+			otherBenefit = other.PIA * max(1.0, claimingFactor(death, otherFRA))
+		}
+	} else {
+		result.SurvivorStartAgeMonths = max(survivorFRA(self.BirthYear), claim)
+	}
+	factor := survivorFactor(result.SurvivorStartAgeMonths, self.BirthYear)
+	if otherClaimed && otherClaim < otherFRA {
+		limit := max(otherBenefit, ribLimFloor*other.PIA)
+		result.SurvivorBenefit = min(other.PIA*factor, limit)
+		if other.PIA*factor > limit && limit > result.OwnBenefit {
+			notes += fmt.Sprintf("RIB-LIM: survivor benefit limited to $%.2f (deceased claimed early).\n", limit)
+		}
+	} else {
+		result.SurvivorBenefit = otherBenefit * factor
+	}
+	return result, notes
+}
+
+// CalculateHouseholdSocialSecurity computes spousal and survivor benefits for a married couple.
+func CalculateHouseholdSocialSecurity(input models.HouseholdSocialSecurityInput) models.HouseholdSocialSecurityResult {
+	worker, workerNotes := householdMemberBenefits(input.Worker, input.Spouse)
+	spouse, spouseNotes := householdMemberBenefits(input.Spouse, input.Worker)
+	notes := ""
+	if workerNotes != "" {
+		notes += "Worker: " + workerNotes
+	}
+	if spouseNotes != "" {
+		notes += "Spouse: " + spouseNotes
+	}
+	return models.HouseholdSocialSecurityResult{
+		Worker:          worker,
+		Spouse:          spouse,
+		CombinedMonthly: worker.OwnBenefit + worker.SpousalBenefit + spouse.OwnBenefit + spouse.SpousalBenefit,
+		Notes:           notes,
+	}
+}
+
+// memberBenefitAt returns one spouse's monthly benefit at ageMonths; after the other's death the larger of the
+// own and survivor benefits continues and the spousal benefit stops.
+func memberBenefitAt(r models.HouseholdMemberResult, ageMonths int, otherAlive bool) float64 {
+	own := 0.0
+	if ageMonths >= r.ClaimAgeMonths {
+		own = r.OwnBenefit
+	}
+	if !otherAlive {
+		if ageMonths >= r.SurvivorStartAgeMonths {
+			return max(own, r.SurvivorBenefit)
+		}
+		return own
+	}
+	if r.SpousalStartAgeMonths > 0 && ageMonths >= r.SpousalStartAgeMonths {
+		if ageMonths < r.ClaimAgeMonths {
+			return r.RestrictedBenefit
+		}
+		return own + r.SpousalBenefit
+	}
+	return own
+}
+
+// memberAlive reports whether a spouse is alive at ageMonths; a DeathAge of 0 means the death is not modeled.
+func memberAlive(m models.HouseholdMember, ageMonths int) bool {
+	return m.DeathAge == 0 || ageMonths < (m.DeathAge+1)*12
+}
+
+// HouseholdBenefitsAt returns each spouse's monthly benefit in the given month of the worker's age, after any
+// trust fund shortfall.
+func HouseholdBenefitsAt(input models.HouseholdSocialSecurityInput, result models.HouseholdSocialSecurityResult, workerAgeMonths int) (worker, spouse float64) {
+	year := input.Worker.BirthYear + workerAgeMonths/12
+	spouseAgeMonths := workerAgeMonths + (input.Worker.BirthYear-input.Spouse.BirthYear)*12
+	workerAlive := memberAlive(input.Worker, workerAgeMonths)
+	spouseAlive := memberAlive(input.Spouse, spouseAgeMonths)
+	if workerAlive {
+		worker = memberBenefitAt(result.Worker, workerAgeMonths, spouseAlive) * TrustFundPayableShare(input.TrustFund, year, input.Worker.BirthYear)
+	}
+	if spouseAlive {
+		spouse = memberBenefitAt(result.Spouse, spouseAgeMonths, workerAlive) * TrustFundPayableShare(input.TrustFund, year, input.Spouse.BirthYear)
+	}
+	return worker, spouse
+}
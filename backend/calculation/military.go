@@ -0,0 +1,204 @@
+package calculation
+
+import (
+	"ferex/backend/models"
+	"fmt"
+	"time"
+)
+
+// OPM variable interest rates on deposits and redeposits, by calendar year
+var opmDepositInterestRates = map[int]float64{
+	1985: 0.13, 1986: 0.11125, 1987: 0.09, 1988: 0.08375, 1989: 0.09125,
+	1990: 0.0875, 1991: 0.08625, 1992: 0.08125, 1993: 0.07125, 1994: 0.0625,
+	1995: 0.07, 1996: 0.06875, 1997: 0.06875, 1998: 0.0675, 1999: 0.0575,
+	2000: 0.065, 2001: 0.06375, 2002: 0.055, 2003: 0.05, 2004: 0.04125,
+	2005: 0.0425, 2006: 0.045, 2007: 0.05, 2008: 0.04875, 2009: 0.04875,
+	2010: 0.03125, 2011: 0.02625, 2012: 0.02375, 2013: 0.01625, 2014: 0.0225,
+	2015: 0.02125, 2016: 0.02, 2017: 0.02, 2018: 0.02375, 2019: 0.02875,
+	2020: 0.0225, 2021: 0.01375, 2022: 0.0175, 2023: 0.03125, 2024: 0.04375,
+	2025: 0.0425,
+}
+
+// Interest on deposits did not accrue before this date for employees hired before 10/1/1982
+var militaryInterestStart = time.Date(1985, time.October, 1, 0, 0, 0, 0, time.UTC)
+
+// Military deposit rate eras: 1999 and 1/1/2000-1/11/2000 carry a higher rate
+var (
+	militaryEra1999 = time.Date(1999, time.January, 1, 0, 0, 0, 0, time.UTC)
+	militaryEra2000 = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+	militaryEraEnd  = time.Date(2000, time.January, 12, 0, 0, 0, 0, time.UTC)
+)
+
+// opmInterestRate returns the OPM rate for a year: 4% through 1947 and 3% through 1984, the embedded variable
+// rate, or the assumed rate when the year is not embedded. CSRS deposits for service before 10/1/1982 stay at 3%.
+func opmInterestRate(year int, preOctober1982 bool, assumed float64) float64 {
+	switch {
+	case year < 1948:
+		return 0.04
+	case year < 1985 || preOctober1982:
+		return 0.03
+	}
+	if rate, ok := opmDepositInterestRates[year]; ok {
+		return rate
+	}
+	return assumed
+}
+
+// accrueDepositInterest accrues interest from start through payoff on OPM's calendar-year schedule: each year's
+// rate applies to the days in that year, and interest compounds at December 31.
+func accrueDepositInterest(principal float64, start, payoff time.Time, preOctober1982 bool, assumed float64) float64 {
+	balance := principal
+	for start.Before(payoff) {
+		yearStart := time.Date(start.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+		yearEnd := yearStart.AddDate(1, 0, 0)
+		end := yearEnd
+		if payoff.Before(end) {
+			end = payoff
+		}
+		rate := opmInterestRate(start.Year(), preOctober1982, assumed)
+		balance *= 1 + rate*end.Sub(start).Hours()/yearEnd.Sub(yearStart).Hours()
+		start = end
+	}
+	return balance - principal
+}
+
+// militaryDepositRate returns the deposit rate for a day of service under FERS or CSRS.
+func militaryDepositRate(system string, day time.Time) float64 {
+	base := 0.03
+	if system != "FERS" {
+		base = 0.07
+	}
+	switch {
+	case !day.Before(militaryEra1999) && day.Before(militaryEra2000):
+		return base + 0.0025
+	case !day.Before(militaryEra2000) && day.Before(militaryEraEnd):
+		return base + 0.004
+	default:
+		return base
+	}
+}
+
+// splitByEra divides a period into slices that share one deposit rate, attributing pay by days.
+func splitByEra(system string, p models.MilitaryPayPeriod) []models.MilitaryDepositPeriod {
+	boundaries := []time.Time{militaryEra1999, militaryEra2000, militaryEraEnd}
+	totalDays := p.EndDate.Sub(p.StartDate).Hours()/24 + 1
+	var slices []models.MilitaryDepositPeriod
+	start := p.StartDate
+	for !start.After(p.EndDate) {
+		end := p.EndDate
+		for _, b := range boundaries {
+			if b.After(start) && !b.After(end) {
+				end = b.AddDate(0, 0, -1)
+				break
+			}
+		}
+		days := end.Sub(start).Hours()/24 + 1
+		pay := p.BasicPay * days / totalDays
+		rate := militaryDepositRate(system, start)
+		slices = append(slices, models.MilitaryDepositPeriod{
+			StartDate:   start,
+			EndDate:     end,
+			BasicPay:    pay,
+			DepositRate: rate,
+			Deposit:     pay * rate,
+		})
+		start = end.AddDate(0, 0, 1)
+	}
+	return slices
+}
+
+// CalculateMilitaryDeposit computes the deposit for post-1956 military service, OPM interest and the break-even point.
+func CalculateMilitaryDeposit(input models.MilitaryDepositCalculationInput) models.MilitaryDepositCalculationResult {
+	var notes string
+	system := input.RetirementSystem
+	if system == "" {
+		system = "FERS"
+	}
+
+	var service models.ServiceDuration
+	var deposits []models.MilitaryDepositPeriod
+	initial := 0.0
+	for _, p := range input.Periods {
+		if p.EndDate.Before(p.StartDate) {
+			notes += fmt.Sprintf("Military period ending %s ends before it starts; ignored.\n", p.EndDate.Format("2006-01-02"))
+			continue
+		}
+		service = addDuration(service, periodDuration(p.StartDate, p.EndDate))
+		for _, slice := range splitByEra(system, p) {
+			deposits = append(deposits, slice)
+			initial += slice.Deposit
+		}
+	}
+	militaryYears := durationYears(service)
+
+	// Two interest-free years from the first civilian hire date
+	var interestFreeUntil time.Time
+	interest := 0.0
+	switch {
+	case input.CivilianHireDate.IsZero():
+		notes += "Civilian hire date not provided; interest could not be determined.\n"
+	default:
+		interestFreeUntil = input.CivilianHireDate.AddDate(2, 0, 0)
+		if input.CivilianHireDate.Before(time.Date(1982, time.October, 1, 0, 0, 0, 0, time.UTC)) {
+			interestFreeUntil = militaryInterestStart
+		}
+		if input.PayoffDate.After(interestFreeUntil) {
+			interest = accrueDepositInterest(initial, interestFreeUntil, input.PayoffDate, false, input.AssumedInterestRate)
+			notes += fmt.Sprintf("Interest accrues from %s at OPM's variable rate for each calendar year.\n", interestFreeUntil.Format("2006-01-02"))
+		} else {
+			notes += "Deposit paid within the interest-free grace period.\n"
+		}
+	}
+	balance := initial + interest
+
+	credited := 0.0
+	if input.DepositPaid {
+		credited = militaryYears
+		notes += fmt.Sprintf("Deposit paid: %.2f years of military service credited.\n", militaryYears)
+	} else if system == "FERS" {
+		notes += "Without the deposit, military service is not creditable under FERS.\n"
+	} else {
+		notes += "Without the deposit, CSRS credit for post-1956 military service is removed at 62 if eligible for Social Security.\n"
+	}
+
+	// Break-even: years of the extra annuity needed to repay the deposit
+	multiplier := input.AnnuityMultiplier
+	if multiplier == 0 {
+		multiplier = 0.01
+		if system != "FERS" {
+			multiplier = 0.02
+		}
+	}
+	increase := input.High3Salary * militaryYears * multiplier
+	lifeExpectancy := input.LifeExpectancy
+	if lifeExpectancy == 0 {
+		lifeExpectancy = 85
+	}
+	breakEvenYears := 0.0
+	breakEvenAge := 0.0
+	lifetime := 0.0
+	if increase > 0 {
+		breakEvenYears = balance / increase
+		breakEvenAge = float64(input.AgeAtRetirement) + breakEvenYears
+		if lifeExpectancy > input.AgeAtRetirement {
+			lifetime = increase * float64(lifeExpectancy-input.AgeAtRetirement)
+		}
+		notes += fmt.Sprintf("Extra annuity of $%.2f/yr repays the deposit in %.1f years (age %.1f).\n", increase, breakEvenYears, breakEvenAge)
+	}
+
+	return models.MilitaryDepositCalculationResult{
+		MilitaryService:         service,
+		MilitaryYears:           militaryYears,
+		CreditedYears:           credited,
+		PeriodDeposits:          deposits,
+		InitialDeposit:          initial,
+		InterestFreeUntil:       interestFreeUntil,
+		InterestAccrued:         interest,
+		BalanceDue:              balance,
+		AnnualAnnuityIncrease:   increase,
+		BreakEvenYears:          breakEvenYears,
+		BreakEvenAge:            breakEvenAge,
+		LifetimeAnnuityIncrease: lifetime,
+		Notes:                   notes,
+	}
+}
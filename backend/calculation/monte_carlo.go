@@ -24,26 +24,44 @@ func RunMonteCarlo(input models.MonteCarloInput) models.MonteCarloResult {
 	finalBalances := make([]float64, sims)
 	depletionByYear := make([]int, years)
 
+	// Social Security the portfolio must replace each year once the trust fund is depleted
+	shortfall := make([]float64, years)
+	for y := range shortfall {
+		year := input.StartYear + y
+		if input.StartYear > 0 && year >= input.SocialSecurityStartYear {
+			shortfall[y] = input.SocialSecurityIncome * (1 - TrustFundPayableShare(input.TrustFund, year, input.BirthYear))
+		}
+	}
+
+	baselineSuccess := 0
 	for i := 0; i < sims; i++ {
 		bal := input.InitialBalance
+		baseline := input.InitialBalance
 		balances[i] = make([]float64, years)
 		depleted := false
 		for y := 0; y < years; y++ {
 			// Random return for this year
 			ret := rand.NormFloat64()*input.ReturnStdDev + input.ExpectedReturn
 			inf := rand.NormFloat64()*input.InflationStdDev + input.InflationMean
+			growth := math.Pow(1+inf, float64(y))
 			if !depleted {
 				bal = bal * (1 + ret)
-				bal -= input.AnnualWithdrawal * math.Pow(1+inf, float64(y))
+				bal -= (input.AnnualWithdrawal + shortfall[y]) * growth
 				if bal < 0 {
 					bal = 0
 					depleted = true
 					depletionByYear[y]++
 				}
 			}
+			if baseline > 0 {
+				baseline = max(baseline*(1+ret)-input.AnnualWithdrawal*growth, 0)
+			}
 			balances[i][y] = bal
 		}
 		finalBalances[i] = bal
+		if baseline > 0 {
+			baselineSuccess++
+		}
 	}
 
 	// Calculate percentiles
@@ -70,6 +88,7 @@ func RunMonteCarlo(input models.MonteCarloInput) models.MonteCarloResult {
 		Percentiles:            percentiles,
 		YearlyBalances:         balances,
 		DepletionProbabilities: depletionProb,
+		BaselineSuccessRate:    float64(baselineSuccess) / float64(sims),
 	}
 }
 
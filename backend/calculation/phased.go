@@ -0,0 +1,128 @@
+package calculation
+
+import (
+	"ferex/backend/models"
+	"fmt"
+	"math"
+)
+
+// Share of the annuity paid during phased employment, and the phased working schedule
+const phasedRetirementPercentage = 0.50
+
+// Minimum share of phased working hours spent mentoring
+const phasedMentoringShare = 0.20
+
+// unreducedAnnuity applies the FERS or CSRS formula with no age reduction (phased, composite and supplemental annuities).
+// Sick leave counts in the computation but not toward the 20 years for the FERS 1.1% multiplier.
+func unreducedAnnuity(pensionType string, high3, years, sickLeaveYears float64, age int) float64 {
+	if pensionType == "FERS" {
+		multiplier := 0.01
+		if age >= 62 && years >= 20 {
+			multiplier = 0.011
+		}
+		return high3 * (years + sickLeaveYears) * multiplier
+	}
+	annuity, _ := csrsComponentAnnuity(high3, years+sickLeaveYears)
+	return annuity
+}
+
+// CalculatePhasedRetirement computes the phased annuity, the half-time schedule and the composite annuity at full retirement.
+func CalculatePhasedRetirement(input models.PhasedRetirementInput) models.PhasedRetirementResult {
+	var notes string
+	age := input.AgeAtPhasedRetirement
+	service, eligibilityService, isPartTime, prorationFactor, serviceNotes := resolveService(input.PensionType, input.ServiceHistory, input.YearsOfService, input.IsPartTime, input.PartTimeProrationFactor)
+	notes += serviceNotes
+	if !isPartTime || prorationFactor <= 0 || prorationFactor > 1 {
+		prorationFactor = 1.0
+	}
+
+	// Only those eligible for an immediate, unreduced voluntary retirement may enter phased retirement
+	eligible := false
+	switch input.PensionType {
+	case "FERS":
+		mraYears, mraMonths := GetMRA(input.BirthYear)
+		eligible = (age >= 60 && eligibilityService >= 20) || (age*12 >= mraYears*12+mraMonths && eligibilityService >= 30)
+		if !eligible {
+			notes += "FERS phased retirement requires 60 with 20 years or MRA with 30 years of service.\n"
+		}
+	case "CSRS":
+		eligible = (age >= 60 && eligibilityService >= 20) || (age >= 55 && eligibilityService >= 30)
+		if !eligible {
+			notes += "CSRS phased retirement requires 60 with 20 years or 55 with 30 years of service.\n"
+		}
+	default:
+		return models.PhasedRetirementResult{Notes: "Phased retirement is available under FERS and CSRS only.\n"}
+	}
+	if !eligible {
+		return models.PhasedRetirementResult{Notes: notes}
+	}
+
+	// Phased annuity: half of the immediate annuity, without sick leave or survivor reduction
+	immediate := unreducedAnnuity(input.PensionType, input.High3Salary, service, 0, age) * prorationFactor
+	if prorationFactor < 1 {
+		notes += fmt.Sprintf("Part-time proration factor applied to service before phased retirement: %.4f\n", prorationFactor)
+	}
+	phased := immediate * phasedRetirementPercentage
+	salary := input.FullTimeSalary * phasedRetirementPercentage
+	hours := fullTimeHoursPerYear * phasedRetirementPercentage
+	mentoring := hours * phasedMentoringShare
+	notes += fmt.Sprintf("Phased annuity: 50%% of $%.2f immediate annuity = $%.2f; half-time salary $%.2f.\n", immediate, phased, salary)
+	notes += fmt.Sprintf("Mentoring requirement: %.1f of %.1f working hours per year.\n", mentoring, hours)
+
+	// COLAs on the phased annuity through full retirement (FERS only from age 62)
+	fullAge := age + int(math.Ceil(input.PhasedYears))
+	phasedWithCOLA := phased
+	for a := age + 1; a <= fullAge; a++ {
+		rate := input.COLARate
+		if input.PensionType == "FERS" {
+			rate = 0
+			if a > 62 {
+				rate = getFERSCOLA(input.COLARate*100) / 100.0
+			}
+		}
+		phasedWithCOLA *= 1 + rate
+	}
+
+	// Second annuity: final High-3 on all service, half-time phased service prorated,
+	// less the share of prior service already paid as the phased annuity
+	finalHigh3 := input.FinalHigh3Salary
+	if finalHigh3 <= 0 {
+		finalHigh3 = input.High3Salary
+	}
+	totalService := service + input.PhasedYears
+	sickLeave, sickNotes := sickLeaveCredit(input.UnusedSickLeaveHours, input.SickLeaveSchedule, input.SickLeaveHoursPerWeek, input.UnusedSickLeaveMonths)
+	notes += sickNotes
+	sickLeaveYears := durationYears(sickLeave)
+	proration := 1.0
+	if totalService > 0 {
+		proration = (service*prorationFactor + input.PhasedYears*phasedRetirementPercentage) / totalService
+	}
+	fullAnnuity := unreducedAnnuity(input.PensionType, finalHigh3, totalService, sickLeaveYears, fullAge) * proration
+	priorShare := unreducedAnnuity(input.PensionType, finalHigh3, service, 0, fullAge) * prorationFactor * phasedRetirementPercentage
+	second := math.Max(fullAnnuity-priorShare, 0)
+	composite := phasedWithCOLA + second
+	notes += fmt.Sprintf("Composite annuity at %d: phased annuity $%.2f (with COLAs) + second annuity $%.2f = $%.2f.\n", fullAge, phasedWithCOLA, second, composite)
+
+	reduction, survivor, survivorNote := getSurvivorReduction(input.PensionType, input.SurvivorBenefitElection, composite, input.SurvivorBaseAmount, beneficiaryYearsYounger(fullAge, input.BeneficiaryAge))
+	if reduction > 0 || input.SurvivorBenefitElection == "insurable interest" {
+		notes += survivorNote + "\n"
+	}
+
+	return models.PhasedRetirementResult{
+		IsEligible:            true,
+		ImmediateAnnuity:      immediate,
+		PhasedAnnuity:         phased,
+		PhasedSalary:          salary,
+		PhasedIncome:          salary + phased,
+		WorkHoursPerYear:      hours,
+		MentoringHours:        mentoring,
+		AgeAtFullRetirement:   fullAge,
+		PhasedAnnuityWithCOLA: phasedWithCOLA,
+		SecondAnnuity:         second,
+		CompositeAnnuity:      composite,
+		SurvivorReduction:     reduction,
+		SurvivorAnnuity:       survivor,
+		AnnualAnnuity:         composite - reduction,
+		Notes:                 notes,
+	}
+}
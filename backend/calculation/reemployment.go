@@ -0,0 +1,71 @@
+package calculation
+
+import (
+	"ferex/backend/models"
+	"fmt"
+	"math"
+)
+
+// Reemployment service needed for a supplemental annuity: one year under 5 U.S.C. 8344(a) (CSRS) and 8468(a) (FERS)
+const supplementalMinimumService = 1.0
+
+// CalculateReemployedAnnuitant applies the salary offset year by year and computes the supplemental annuity earned by reemployment.
+func CalculateReemployedAnnuitant(input models.ReemployedAnnuitantInput) models.ReemployedAnnuitantResult {
+	var notes string
+	if input.EndAge < input.StartAge {
+		return models.ReemployedAnnuitantResult{Notes: "Reemployment must end at or after the age it starts.\n"}
+	}
+	if input.OffsetWaived {
+		notes += "Salary offset waived: full salary and annuity paid; no supplemental annuity is earned.\n"
+	} else {
+		notes += "Salary reduced by the annuity (salary offset).\n"
+	}
+
+	var years []models.ReemploymentYear
+	salary := input.AnnualSalary
+	annuity := input.AnnualAnnuity
+	totalEarned, totalSalary := 0.0, 0.0
+	for age := input.StartAge; age <= input.EndAge; age++ {
+		if age > input.StartAge {
+			salary *= 1 + input.SalaryGrowthRate
+			rate := input.COLARate
+			if input.PensionType == "FERS" {
+				rate = 0
+				if age > 62 {
+					rate = getFERSCOLA(input.COLARate*100) / 100.0
+				}
+			}
+			annuity *= 1 + rate
+		}
+		// Each age is one year of reemployment service, completed by the end of the year
+		service := float64(age - input.StartAge + 1)
+		offset := 0.0
+		if !input.OffsetWaived {
+			offset = math.Min(annuity, salary)
+		}
+		years = append(years, models.ReemploymentYear{
+			Age:                  age,
+			Salary:               salary,
+			AnnuityOffset:        offset,
+			SalaryPaid:           salary - offset,
+			Annuity:              annuity,
+			SupplementalEligible: !input.OffsetWaived && service >= supplementalMinimumService,
+		})
+		totalEarned += salary - offset
+		totalSalary += salary
+	}
+
+	// Supplemental annuity: one year or more of reemployment without a waiver, computed with the
+	// system's own formula (tiered for CSRS) on reemployment service and the average salary it earned
+	result := models.ReemployedAnnuitantResult{Years: years, TotalEarnedIncome: totalEarned}
+	service := float64(len(years))
+	if len(years) > 0 && years[len(years)-1].SupplementalEligible {
+		averagePay := totalSalary / service
+		result.SupplementalEligible = true
+		result.SupplementalStartAge = input.EndAge + 1
+		result.SupplementalAnnuity = unreducedAnnuity(input.PensionType, averagePay, service, 0, result.SupplementalStartAge)
+		notes += fmt.Sprintf("Supplemental annuity of $%.2f/yr from age %d (%.0f years of reemployment, $%.2f average pay).\n", result.SupplementalAnnuity, result.SupplementalStartAge, service, averagePay)
+	}
+	result.Notes = notes
+	return result
+}
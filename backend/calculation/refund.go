@@ -0,0 +1,93 @@
+package calculation
+
+import (
+	"ferex/backend/models"
+	"fmt"
+	"math"
+)
+
+// Dispersion of the Gompertz survival curve, in years
+const gompertzDispersion = 9.5
+
+// survivalProbability returns the chance of living from fromAge to toAge under the chosen mortality model.
+func survivalProbability(model string, fromAge, toAge, lifeExpectancy int) float64 {
+	if toAge <= fromAge {
+		return 1
+	}
+	if model == "gompertz" {
+		x, t, m := float64(fromAge), float64(toAge), float64(lifeExpectancy)
+		return math.Exp(math.Exp((x-m)/gompertzDispersion) * (1 - math.Exp((t-x)/gompertzDispersion)))
+	}
+	if toAge < lifeExpectancy {
+		return 1
+	}
+	return 0
+}
+
+// CompareRefundWithDeferredAnnuity weighs an after-tax refund of contributions against the present value of a deferred FERS annuity.
+func CompareRefundWithDeferredAnnuity(input models.RefundComparisonInput) models.RefundComparisonResult {
+	var notes string
+	lifeExpectancy := input.LifeExpectancy
+	if lifeExpectancy == 0 {
+		lifeExpectancy = 85
+	}
+	model := input.MortalityModel
+	if model == "" {
+		model = "fixed"
+	}
+
+	// Contributions come back tax-free; the interest is taxed, with the 10% penalty before the year of age 55
+	deferred := CalculateFERSDeferred(models.FERSDeferredCalculationInput{
+		FERSInput:       input.FERSInput,
+		SeparationDate:  input.SeparationDate,
+		CommencementAge: input.CommencementAge,
+	})
+	separationAge := deferred.AgeAtSeparation
+	refundGross := input.FERSInput.EmployeeContributions + input.RefundInterest
+	refundTax := input.RefundInterest * input.TaxRate
+	if separationAge < 55 {
+		refundTax += input.RefundInterest * 0.10
+		notes += "Refund interest is subject to the 10% early withdrawal penalty (separated before 55).\n"
+	}
+	refundNet := refundGross - refundTax
+
+	// Present value at separation of the deferred annuity, discounted and weighted by survival
+	diet := getFERSCOLA(input.COLARate*100) / 100.0
+	pv := 0.0
+	annuity := deferred.AnnualAnnuity
+	maxAge := lifeExpectancy
+	if model == "gompertz" {
+		maxAge = 110
+	}
+	for age := deferred.CommencementAge; age < maxAge && annuity > 0; age++ {
+		if age > 62 {
+			annuity *= 1 + diet
+		}
+		years := float64(age - separationAge)
+		pv += annuity * survivalProbability(model, separationAge, age, lifeExpectancy) / math.Pow(1+input.DiscountRate, years)
+	}
+	if deferred.AnnualAnnuity == 0 {
+		notes += "No deferred annuity is payable; the refund is the only benefit.\n"
+	}
+	notes += fmt.Sprintf("Deferred annuity of $%.2f/yr from age %d has a present value of $%.2f (%.2f%% discount, %s mortality to %d).\n",
+		deferred.AnnualAnnuity, deferred.CommencementAge, pv, input.DiscountRate*100, model, lifeExpectancy)
+
+	recommendation := "deferred annuity"
+	if refundNet > pv {
+		recommendation = "refund"
+	} else {
+		notes += "Taking a refund forfeits the service credit; a later redeposit would be needed to restore it.\n"
+	}
+
+	return models.RefundComparisonResult{
+		RefundGross:          refundGross,
+		RefundTax:            refundTax,
+		RefundNet:            refundNet,
+		DeferredAnnuity:      deferred.AnnualAnnuity,
+		CommencementAge:      deferred.CommencementAge,
+		DeferredPresentValue: pv,
+		Advantage:            pv - refundNet,
+		Recommendation:       recommendation,
+		Notes:                notes,
+	}
+}
@@ -2,22 +2,78 @@ package calculation
 
 import (
 	"ferex/backend/models"
+	"fmt"
 )
 
 // CalculateRetirementProjection orchestrates all modules for a full retirement projection
 func CalculateRetirementProjection(input models.RetirementCalculationInput) models.RetirementCalculationResult {
 	fersResult := CalculateFERSPension(input.FERSInput)
 	csrsResult := CalculateCSRS(input.CSRSInput)
-	srsResult := CalculateSRS(input.SRSInput)
+	srsInput := input.SRSInput
+	if srsInput.RetirementType == "" && input.FERSInput.High3Salary > 0 {
+		srsInput.RetirementType = fersResult.RetirementType
+	}
+	// Salary paid to a reemployed annuitant is earned income for the SRS earnings test
+	var reemploymentResult models.ReemployedAnnuitantResult
+	if input.ReemploymentInput.AnnualSalary > 0 {
+		reemploymentResult = CalculateReemployedAnnuitant(input.ReemploymentInput)
+		if srsInput.ProjectedEarnedIncome == 0 && len(reemploymentResult.Years) > 0 {
+			srsInput.ProjectedEarnedIncome = reemploymentResult.Years[0].SalaryPaid
+		}
+	}
+	srsResult := CalculateSRS(srsInput)
 	tspResult := CalculateTSP(input.TSPInput)
-	taxResult := CalculateTax(input.TaxInput)
-	ssResult := CalculateSocialSecurity(input.SocialSecurityInput)
+	// A projection-wide trust fund policy applies to every Social Security stream without its own
+	ssInput := input.SocialSecurityInput
+	if ssInput.TrustFund.DepletionYear == 0 {
+		ssInput.TrustFund = input.TrustFund
+	}
+	ssResult := CalculateSocialSecurity(ssInput)
 	colaResult := CalculateCOLA(input.COLAInput)
-	survivorResult := CalculateSurvivorBenefit(input.SurvivorInput)
+	survivorInput := input.SurvivorInput
+	if survivorInput.SocialSecurity != nil && survivorInput.SocialSecurity.TrustFund.DepletionYear == 0 {
+		household := *survivorInput.SocialSecurity
+		household.TrustFund = ssInput.TrustFund
+		survivorInput.SocialSecurity = &household
+	}
+	survivorResult := CalculateSurvivorBenefit(survivorInput)
 	healthResult := CalculateHealthPremiums(input.HealthInput)
 
+	// Disability retirees are paid under the disability formula rather than the earned annuity
+	var disabilityResult models.FERSDisabilityCalculationResult
+	fersAnnuity := fersResult.AnnualPension
+	fersTaxFree := fersResult.MonthlyTaxFreeAmount
+	if input.FERSInput.RetirementOption == "Disability" {
+		disabilityResult = CalculateFERSDisability(models.FERSDisabilityCalculationInput{
+			High3Salary:             input.FERSInput.High3Salary,
+			YearsOfService:          fersResult.ComputationServiceYears,
+			AgeAtDisability:         input.FERSInput.AgeAtRetirement,
+			SSDisabilityBenefit:     input.FERSInput.SSDisabilityBenefit,
+			COLARate:                input.COLAInput.COLARate,
+			SurvivorBenefitElection: input.FERSInput.SurvivorBenefitElection,
+			BeneficiaryAge:          input.FERSInput.BeneficiaryAge,
+			EmployeeContributions:   input.FERSInput.EmployeeContributions,
+		})
+		fersAnnuity = disabilityResult.FirstYearAnnuity
+		fersTaxFree = disabilityResult.MonthlyTaxFreeAmount
+	}
+
+	// The Simplified Method exclusion keeps recovered contributions out of taxable pension
+	taxInput := input.TaxInput
+	if taxInput.TaxablePension == 0 && taxInput.GrossPension == 0 {
+		taxInput.GrossPension = fersAnnuity + csrsResult.AnnualPension
+	}
+	if taxInput.TaxablePension == 0 && taxInput.AnnuityTaxFree == 0 {
+		taxInput.AnnuityTaxFree = (fersTaxFree + csrsResult.MonthlyTaxFreeAmount) * 12
+	}
+	taxResult := CalculateTax(taxInput)
+
 	// Aggregate income streams (example: sum of annuities, SS, TSP withdrawals)
-	totalIncome := fersResult.AnnualPension + csrsResult.AnnualPension + srsResult.AnnualSRSAmount + tspResult.AnnualWithdrawalIncome + ssResult.ClaimingAmount
+	claimYear := SocialSecurityClaimYear(ssInput, ssResult)
+	ssScheduled := ssResult.ClaimingAmount * 12
+	ssPayable := ssScheduled * TrustFundPayableShare(ssInput.TrustFund, claimYear, ssInput.BirthYear)
+	scheduledIncome := fersAnnuity + csrsResult.AnnualPension + srsResult.AnnualSRSAmount + tspResult.AnnualWithdrawalIncome + ssScheduled
+	totalIncome := scheduledIncome - ssScheduled + ssPayable
 
 	// Subtract health premiums
 	netIncome := totalIncome
@@ -32,11 +88,50 @@ func CalculateRetirementProjection(input models.RetirementCalculationInput) mode
 		netIncome -= taxResult.StateTaxOwed
 	}
 
-	notes := fersResult.Notes + "\n" + csrsResult.Notes + "\n" + srsResult.Notes + "\n" + tspResult.Notes + "\n" + taxResult.Notes + "\n" + ssResult.Notes + "\n" + colaResult.Notes + "\n" + survivorResult.Notes + "\n" + healthResult.Notes
+	notes := fersResult.Notes + "\n" + disabilityResult.Notes + "\n" + csrsResult.Notes + "\n" + srsResult.Notes + "\n" + tspResult.Notes + "\n" + taxResult.Notes + "\n" + ssResult.Notes + "\n" + colaResult.Notes + "\n" + survivorResult.Notes + "\n" + healthResult.Notes + "\n" + reemploymentResult.Notes
+
+	// Lifetime Social Security with and without the shortfall, in today's dollars
+	lifeExpectancy := input.LifeExpectancy
+	if lifeExpectancy == 0 {
+		lifeExpectancy = input.SurvivorInput.RetireeAgeAtDeath
+	}
+	if lifeExpectancy == 0 {
+		lifeExpectancy = 85
+	}
+	lifetimeScheduled, lifetimePayable := 0.0, 0.0
+	if ssResult.ClaimingAmount > 0 {
+		for year := claimYear; year <= ssInput.BirthYear+lifeExpectancy; year++ {
+			lifetimeScheduled += ssResult.ClaimingAmount * 12
+			lifetimePayable += ssResult.ClaimingAmount * 12 * TrustFundPayableShare(ssInput.TrustFund, year, ssInput.BirthYear)
+		}
+	}
 
 	var monteCarloResult models.MonteCarloResult
 	if input.MonteCarloInput.NumSimulations > 0 {
-		monteCarloResult = RunMonteCarlo(input.MonteCarloInput)
+		mcInput := input.MonteCarloInput
+		if mcInput.TrustFund.DepletionYear == 0 {
+			mcInput.TrustFund = ssInput.TrustFund
+		}
+		if mcInput.SocialSecurityIncome == 0 {
+			mcInput.SocialSecurityIncome = ssResult.ClaimingAmount * 12
+		}
+		if mcInput.SocialSecurityStartYear == 0 {
+			mcInput.SocialSecurityStartYear = claimYear
+		}
+		if mcInput.BirthYear == 0 {
+			mcInput.BirthYear = ssInput.BirthYear
+		}
+		if mcInput.StartYear == 0 && ssInput.BirthYear > 0 {
+			mcInput.StartYear = ssInput.BirthYear + ssInput.CurrentAge
+		}
+		monteCarloResult = RunMonteCarlo(mcInput)
+	}
+
+	if ssInput.TrustFund.DepletionYear > 0 && lifetimeScheduled > 0 {
+		notes += fmt.Sprintf("\nTrust fund shortfall from %d: lifetime Social Security $%.2f vs $%.2f scheduled (%.1f%% less)", ssInput.TrustFund.DepletionYear, lifetimePayable, lifetimeScheduled, (1-lifetimePayable/lifetimeScheduled)*100)
+		if input.MonteCarloInput.NumSimulations > 0 {
+			notes += fmt.Sprintf("; Monte Carlo success %.1f%% vs %.1f%% with scheduled benefits", monteCarloResult.SuccessRate*100, monteCarloResult.BaselineSuccessRate*100)
+		}
 	}
 
 	return models.RetirementCalculationResult{
@@ -49,10 +144,16 @@ func CalculateRetirementProjection(input models.RetirementCalculationInput) mode
 		COLAResult:            colaResult,
 		SurvivorResult:        survivorResult,
 		HealthResult:          healthResult,
+		DisabilityResult:      disabilityResult,
+		ReemploymentResult:    reemploymentResult,
 		MonteCarloResult:      monteCarloResult,
 		NetAfterTaxIncome:     netIncome,
 		EffectiveTaxRate:      taxResult.EffectiveTaxRate,
 		TotalRetirementIncome: totalIncome,
 		Notes:                 notes,
+
+		ScheduledRetirementIncome: scheduledIncome,
+		LifetimeScheduledSS:       lifetimeScheduled,
+		LifetimePayableSS:         lifetimePayable,
 	}
 }
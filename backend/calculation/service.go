@@ -0,0 +1,252 @@
+package calculation
+
+import (
+	"ferex/backend/models"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Full-time hours in a work year, used for the part-time proration factor and the sick leave chart
+const fullTimeHoursPerYear = 2087.0
+
+// Firefighters on a 144-hour biweekly tour convert sick leave with the 2756-hour chart
+const firefighterHoursPerYear = 2756.0
+
+// Part-time service on or after this date is prorated in the annuity computation
+var partTimeProrationStart = time.Date(1986, time.April, 7, 0, 0, 0, 0, time.UTC)
+
+// FERS deposits for non-deduction service are only allowed for service before this date
+var fersDepositCutoff = time.Date(1989, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// CSRS non-deduction service before this date is credited without a deposit, with a 10% penalty
+var csrsDepositCutoff = time.Date(1982, time.October, 1, 0, 0, 0, 0, time.UTC)
+
+// CSRS refunded service ending before this date is credited without a redeposit, with an actuarial reduction
+var csrsRedepositCutoff = time.Date(1991, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+// periodDuration returns the inclusive length of a period in OPM years/months/days.
+func periodDuration(start, end time.Time) models.ServiceDuration {
+	if end.Before(start) {
+		return models.ServiceDuration{}
+	}
+	end = end.AddDate(0, 0, 1)
+	years := end.Year() - start.Year()
+	months := int(end.Month()) - int(start.Month())
+	days := end.Day() - start.Day()
+	if days < 0 {
+		days += 30
+		months--
+	}
+	if months < 0 {
+		months += 12
+		years--
+	}
+	return models.ServiceDuration{Years: years, Months: months, Days: days}
+}
+
+// addDuration sums two durations, carrying 30 days to a month and 12 months to a year.
+func addDuration(a, b models.ServiceDuration) models.ServiceDuration {
+	days := a.Days + b.Days
+	months := a.Months + b.Months + days/30
+	years := a.Years + b.Years + months/12
+	return models.ServiceDuration{Years: years, Months: months % 12, Days: days % 30}
+}
+
+// durationYears converts a duration to fractional years.
+func durationYears(d models.ServiceDuration) float64 {
+	return float64(d.Years) + float64(d.Months)/12.0 + float64(d.Days)/360.0
+}
+
+// serviceCreditFor reports whether a period counts toward eligibility and computation. firstHire is the start
+// of the earliest civilian period in the history.
+func serviceCreditFor(system string, p models.ServicePeriod, firstHire time.Time) (eligibility, computation bool, note string) {
+	isFERS := system == "FERS"
+	switch p.ServiceType {
+	case "fulltime", "parttime", "":
+		if !p.Refunded || p.DepositPaid {
+			return true, true, ""
+		}
+		if isFERS {
+			return false, false, "Refunded FERS service without redeposit is not creditable"
+		}
+		if p.EndDate.Before(csrsRedepositCutoff) {
+			return true, true, "Refunded CSRS service before 3/1/91 without redeposit is credited with an actuarial reduction"
+		}
+		return true, false, "Refunded CSRS service without redeposit counts for eligibility only"
+	case "temporary":
+		if isFERS {
+			if !p.StartDate.Before(fersDepositCutoff) {
+				return false, false, "FERS non-deduction service after 1988 is not creditable"
+			}
+			if !p.DepositPaid {
+				return false, false, "FERS non-deduction service without deposit is not creditable"
+			}
+			return true, true, ""
+		}
+		if !p.DepositPaid && p.EndDate.Before(csrsDepositCutoff) {
+			return true, true, "CSRS non-deduction service before 10/1/82 without deposit is credited with a 10% reduction"
+		}
+		if !p.DepositPaid {
+			return true, false, "CSRS non-deduction service without deposit counts for eligibility only"
+		}
+		return true, true, ""
+	case "military":
+		if !p.DepositPaid && !isFERS && !firstHire.IsZero() && firstHire.Before(csrsDepositCutoff) {
+			return true, true, "CSRS military service without deposit (first hired before 10/1/82) is credited, but removed at 62 if eligible for Social Security"
+		}
+		if !p.DepositPaid {
+			return false, false, "Military service without deposit is not creditable"
+		}
+		return true, true, ""
+	default:
+		return false, false, fmt.Sprintf("Unknown service type %q ignored", p.ServiceType)
+	}
+}
+
+// CalculateServiceCredit derives creditable service and the part-time proration factor from dated service periods.
+func CalculateServiceCredit(input models.ServiceHistoryInput) models.ServiceCreditResult {
+	var notes string
+	var eligibility, computation models.ServiceDuration
+	fullHours := 0.0
+	actualHours := 0.0
+	isPartTime := false
+	var firstHire time.Time
+	for _, p := range input.Periods {
+		if p.ServiceType != "military" && (firstHire.IsZero() || p.StartDate.Before(firstHire)) {
+			firstHire = p.StartDate
+		}
+	}
+	var removedAt62 models.ServiceDuration
+
+	for _, p := range input.Periods {
+		countsForEligibility, countsForComputation, note := serviceCreditFor(input.RetirementSystem, p, firstHire)
+		if note != "" {
+			notes += fmt.Sprintf("%s (%s to %s).\n", note, p.StartDate.Format("2006-01-02"), p.EndDate.Format("2006-01-02"))
+		}
+		d := periodDuration(p.StartDate, p.EndDate)
+		if countsForEligibility {
+			eligibility = addDuration(eligibility, d)
+		}
+		if !countsForComputation {
+			continue
+		}
+		computation = addDuration(computation, d)
+		if p.ServiceType == "military" && !p.DepositPaid {
+			removedAt62 = addDuration(removedAt62, d)
+		}
+
+		hours := durationYears(d) * fullTimeHoursPerYear
+		fullHours += hours
+		if p.ServiceType != "parttime" || p.ScheduledHoursPerWeek <= 0 || p.ScheduledHoursPerWeek >= 40 || p.EndDate.Before(partTimeProrationStart) {
+			actualHours += hours
+			continue
+		}
+		// Only the portion on or after 4/7/1986 is prorated
+		prorated := hours
+		if p.StartDate.Before(partTimeProrationStart) {
+			before := durationYears(periodDuration(p.StartDate, partTimeProrationStart.AddDate(0, 0, -1))) * fullTimeHoursPerYear
+			actualHours += before
+			prorated -= before
+		}
+		actualHours += prorated * p.ScheduledHoursPerWeek / 40.0
+		isPartTime = true
+	}
+
+	prorationFactor := 1.0
+	if isPartTime && fullHours > 0 {
+		prorationFactor = actualHours / fullHours
+		notes += fmt.Sprintf("Part-time proration factor from service history: %.4f\n", prorationFactor)
+	}
+
+	// Odd days are dropped from computation service
+	computationWhole := models.ServiceDuration{Years: computation.Years, Months: computation.Months}
+
+	return models.ServiceCreditResult{
+		EligibilityService:         eligibility,
+		ComputationService:         computationWhole,
+		ComputationServiceWithDays: computation,
+		EligibilityYears:           durationYears(eligibility),
+		ComputationYears:           durationYears(computationWhole),
+		ProrationFactor:            prorationFactor,
+		IsPartTime:                 isPartTime,
+		MilitaryRemovedAt62:        removedAt62,
+		Notes:                      notes,
+	}
+}
+
+// withoutUnpaidMilitary returns the service history less military periods whose deposit was not paid.
+func withoutUnpaidMilitary(history []models.ServicePeriod) []models.ServicePeriod {
+	var result []models.ServicePeriod
+	for _, p := range history {
+		if p.ServiceType != "military" || p.DepositPaid {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// resolveService returns computation years, eligibility years and proration, preferring a service history over hand-entered values.
+func resolveService(system string, history []models.ServicePeriod, yearsOfService float64, isPartTime bool, prorationFactor float64) (computationYears, eligibilityYears float64, partTime bool, factor float64, notes string) {
+	if len(history) == 0 {
+		return yearsOfService, yearsOfService, isPartTime, prorationFactor, ""
+	}
+	credit := CalculateServiceCredit(models.ServiceHistoryInput{RetirementSystem: system, Periods: history})
+	notes = credit.Notes
+	notes += fmt.Sprintf("Creditable service from history: %.2f years for eligibility, %.2f years for computation.\n", credit.EligibilityYears, credit.ComputationYears)
+	return credit.ComputationYears, credit.EligibilityYears, credit.IsPartTime, credit.ProrationFactor, notes
+}
+
+// durationFromYears converts fractional years to years/months/days.
+func durationFromYears(years float64) models.ServiceDuration {
+	totalDays := int(math.Round(years * 360))
+	return models.ServiceDuration{Years: totalDays / 360, Months: totalDays % 360 / 30, Days: totalDays % 30}
+}
+
+// sickLeaveCredit converts unused sick leave to service with OPM's conversion chart for the work schedule.
+// Hours take precedence over the legacy months input.
+func sickLeaveCredit(hours float64, schedule string, hoursPerWeek float64, months int) (models.ServiceDuration, string) {
+	if hours <= 0 {
+		return models.ServiceDuration{Years: months / 12, Months: months % 12}, ""
+	}
+	hoursPerYear := fullTimeHoursPerYear
+	switch schedule {
+	case "firefighter":
+		hoursPerYear = firefighterHoursPerYear
+	case "parttime":
+		if hoursPerWeek > 0 && hoursPerWeek < 40 {
+			hoursPerYear = fullTimeHoursPerYear * hoursPerWeek / 40.0
+		}
+	}
+	// Chart entries are rounded to whole hours: a month is 1/12 and a day 1/360 of the work year
+	years := int(hours / hoursPerYear)
+	remaining := hours - float64(years)*hoursPerYear
+	monthsCredited := 0
+	for m := 11; m >= 1; m-- {
+		if remaining >= math.Round(float64(m)*hoursPerYear/12) {
+			monthsCredited = m
+			remaining -= math.Round(float64(m) * hoursPerYear / 12)
+			break
+		}
+	}
+	days := 0
+	for d := 29; d >= 1; d-- {
+		if remaining >= math.Round(float64(d)*hoursPerYear/360) {
+			days = d
+			break
+		}
+	}
+	credit := models.ServiceDuration{Years: years, Months: monthsCredited, Days: days}
+	return credit, fmt.Sprintf("%.0f hours of sick leave (%.0f-hour chart) = %d years, %d months, %d days.\n", hours, hoursPerYear, years, monthsCredited, days)
+}
+
+// computationWithSickLeave adds sick leave to computation service in years/months/days and drops the odd days.
+func computationWithSickLeave(system string, history []models.ServicePeriod, yearsOfService float64, sickLeave models.ServiceDuration) (models.ServiceDuration, float64) {
+	service := durationFromYears(yearsOfService)
+	if len(history) > 0 {
+		service = CalculateServiceCredit(models.ServiceHistoryInput{RetirementSystem: system, Periods: history}).ComputationServiceWithDays
+	}
+	total := addDuration(service, sickLeave)
+	total.Days = 0
+	return total, durationYears(total)
+}
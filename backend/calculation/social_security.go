@@ -2,76 +2,220 @@ package calculation
 
 import (
 	"ferex/backend/models"
+	"fmt"
 	"math"
+	"sort"
+	"strings"
 )
 
-// SSA 2025 bend points for PIA (Primary Insurance Amount)
-var piaBendPoints = []float64{1115, 6721} // 2025 values: first, second
+// National Average Wage Index by year, as published by SSA
+var averageWageIndex = map[int]float64{
+	1951: 2799.16, 1952: 2973.32, 1953: 3139.44, 1954: 3155.64, 1955: 3301.44,
+	1956: 3532.36, 1957: 3641.72, 1958: 3673.80, 1959: 3855.80, 1960: 4007.12,
+	1961: 4086.76, 1962: 4291.40, 1963: 4396.64, 1964: 4576.32, 1965: 4658.72,
+	1966: 4938.36, 1967: 5213.44, 1968: 5571.76, 1969: 5893.76, 1970: 6186.24,
+	1971: 6497.08, 1972: 7133.80, 1973: 7580.16, 1974: 8030.76, 1975: 8630.92,
+	1976: 9226.48, 1977: 9779.44, 1978: 10556.03, 1979: 11479.46, 1980: 12513.46,
+	1981: 13773.10, 1982: 14531.34, 1983: 15239.24, 1984: 16135.07, 1985: 16822.51,
+	1986: 17321.82, 1987: 18426.51, 1988: 19334.04, 1989: 20099.55, 1990: 21027.98,
+	1991: 21811.60, 1992: 22935.42, 1993: 23132.67, 1994: 23753.53, 1995: 24705.66,
+	1996: 25913.90, 1997: 27426.00, 1998: 28861.44, 1999: 30469.84, 2000: 32154.82,
+	2001: 32921.92, 2002: 33252.09, 2003: 34064.95, 2004: 35648.55, 2005: 36952.94,
+	2006: 38651.41, 2007: 40405.48, 2008: 41334.97, 2009: 40711.61, 2010: 41673.83,
+	2011: 42979.61, 2012: 44321.67, 2013: 44888.16, 2014: 46481.52, 2015: 48098.63,
+	2016: 48642.15, 2017: 50321.89, 2018: 52145.80, 2019: 54099.99, 2020: 55628.60,
+	2021: 60575.07, 2022: 63795.13, 2023: 66621.80,
+}
+
+// Latest year with a published average wage index
+const latestAWIYear = 2023
+
+// Wage growth used to project the AWI and future earnings when none is given
+const defaultWageGrowth = 0.03
+
+// Contribution and benefit base (maximum taxable earnings) by year, as published by SSA
+var contributionBenefitBase = map[int]float64{
+	1951: 3600, 1952: 3600, 1953: 3600, 1954: 3600, 1955: 4200,
+	1956: 4200, 1957: 4200, 1958: 4200, 1959: 4800, 1960: 4800,
+	1961: 4800, 1962: 4800, 1963: 4800, 1964: 4800, 1965: 4800,
+	1966: 6600, 1967: 6600, 1968: 7800, 1969: 7800, 1970: 7800,
+	1971: 7800, 1972: 9000, 1973: 10800, 1974: 13200, 1975: 14100,
+	1976: 15300, 1977: 16500, 1978: 17700, 1979: 22900, 1980: 25900,
+	1981: 29700, 1982: 32400, 1983: 35700, 1984: 37800, 1985: 39600,
+	1986: 42000, 1987: 43800, 1988: 45000, 1989: 48000, 1990: 51300,
+	1991: 53400, 1992: 55500, 1993: 57600, 1994: 60600, 1995: 61200,
+	1996: 62700, 1997: 65400, 1998: 68400, 1999: 72600, 2000: 76200,
+	2001: 80400, 2002: 84900, 2003: 87000, 2004: 87900, 2005: 90000,
+	2006: 94200, 2007: 97500, 2008: 102000, 2009: 106800, 2010: 106800,
+	2011: 106800, 2012: 110100, 2013: 113700, 2014: 117000, 2015: 118500,
+	2016: 118500, 2017: 127200, 2018: 128400, 2019: 132900, 2020: 137700,
+	2021: 142800, 2022: 147000, 2023: 160200, 2024: 168600, 2025: 176100,
+}
+
+// Latest year with a published contribution and benefit base
+const latestBenefitBaseYear = 2025
+
+// The base for later years is the 1994 base scaled by the AWI two years before relative to 1992
+const benefitBaseAnchorYear = 1994
+
+// Social Security COLAs by the year of the December they take effect
+var socialSecurityCOLAs = map[int]float64{
+	2000: 0.035, 2001: 0.026, 2002: 0.014, 2003: 0.021, 2004: 0.027,
+	2005: 0.041, 2006: 0.033, 2007: 0.023, 2008: 0.058, 2009: 0, 2010: 0,
+	2011: 0.036, 2012: 0.017, 2013: 0.015, 2014: 0.017, 2015: 0, 2016: 0.003,
+	2017: 0.020, 2018: 0.028, 2019: 0.016, 2020: 0.013, 2021: 0.059,
+	2022: 0.087, 2023: 0.032, 2024: 0.025,
+}
+
+// 1979 PIA bend points; later years scale them by the AWI two years before eligibility relative to 1977
+var piaBaseBendPoints = []float64{180, 1085}
+
+// AWI year the base bend points are tied to
+const piaBaseAWIYear = 1977
+
 var piaPercentages = []float64{0.9, 0.32, 0.15}
 
-// Full Retirement Age lookup by birth year (simplified)
-func getFRA(birthYear int) int {
+// averageWage returns the year's AWI, projecting years after the latest published one with wage growth.
+func averageWage(year int, growth float64) float64 {
+	if awi, ok := averageWageIndex[year]; ok {
+		return awi
+	}
+	if year < 1951 {
+		return averageWageIndex[1951]
+	}
+	return averageWageIndex[latestAWIYear] * math.Pow(1+growth, float64(year-latestAWIYear))
+}
+
+// taxableMaximum returns the year's contribution and benefit base. Years after the latest published one follow
+// the statutory formula on the projected AWI, rounded to a multiple of $300, and never fall below the last base.
+func taxableMaximum(year int, growth float64) float64 {
+	if base, ok := contributionBenefitBase[year]; ok {
+		return base
+	}
+	if year < 1951 {
+		return contributionBenefitBase[1951]
+	}
+	scale := averageWage(year-2, growth) / averageWageIndex[benefitBaseAnchorYear-2]
+	projected := math.Round(contributionBenefitBase[benefitBaseAnchorYear]*scale/300) * 300
+	return math.Max(projected, contributionBenefitBase[latestBenefitBaseYear])
+}
+
+// piaBendPoints returns the PIA bend points for the year a worker turns 62, rounded to the dollar.
+func piaBendPoints(eligibilityYear int, growth float64) []float64 {
+	scale := averageWage(eligibilityYear-2, growth) / averageWageIndex[piaBaseAWIYear]
+	return []float64{math.Round(piaBaseBendPoints[0] * scale), math.Round(piaBaseBendPoints[1] * scale)}
+}
+
+// socialSecurityCOLA returns the COLA taking effect in December of the year, using the assumed rate
+// for years without a published COLA.
+func socialSecurityCOLA(year int, assumed float64) float64 {
+	if cola, ok := socialSecurityCOLAs[year]; ok {
+		return cola
+	}
+	return assumed
+}
+
+// floorDime rounds a benefit amount down to the dime as SSA does.
+func floorDime(amount float64) float64 {
+	return math.Floor(amount*10+1e-9) / 10
+}
+
+// FullRetirementAge returns the Social Security full retirement age (years, months) for a birth year.
+// This is the single source of FRA for the calculators and the app.
+func FullRetirementAge(birthYear int) (years, months int) {
 	switch {
+	case birthYear <= 1937:
+		return 65, 0
+	case birthYear <= 1942:
+		return 65, (birthYear - 1937) * 2
 	case birthYear <= 1954:
-		return 66
-	case birthYear >= 1960:
-		return 67
+		return 66, 0
+	case birthYear <= 1959:
+		return 66, (birthYear - 1954) * 2
 	default:
-		return 66 + (birthYear-1954)/2 // 1955: 66+2mo, 1956: 66+4mo, etc. (approximate)
+		return 67, 0
 	}
 }
 
-// Early/late claiming factors (monthly)
-func claimingFactor(claimAge, fra int) float64 {
-	if claimAge == fra {
-		return 1.0
-	} else if claimAge < fra {
-		reduction := float64(fra-claimAge) * 0.0056 * 12 // 0.56% per month, 6.7% per year for first 36 months
-		return math.Max(1.0-reduction, 0.7)              // SSA minimum is 70% at 62
-	} else {
-		increase := float64(claimAge-fra) * 0.008 * 12 // 0.8% per month, 8% per year
-		return math.Min(1.0+increase, 1.24)            // SSA max is 124% at 70
+// claimingFactor returns the benefit as a share of the PIA for claiming at claimMonths of age with an FRA of fraMonths:
+// 5/9 of 1% per month for the first 36 months early, 5/12 of 1% per month beyond, and 2/3 of 1% per month of delay up to 70.
+// Claims before 62 are treated as claims at 62, the earliest age for retirement benefits.
+func claimingFactor(claimMonths, fraMonths int) float64 {
+	claimMonths = min(max(claimMonths, 62*12), 70*12)
+	if claimMonths < fraMonths {
+		early := fraMonths - claimMonths
+		return 1.0 - float64(min(early, 36))*5.0/900.0 - float64(max(early-36, 0))*5.0/1200.0
 	}
+	return 1.0 + float64(claimMonths-fraMonths)*2.0/300.0
 }
 
-// Estimate AIME from earnings history or salary
-func estimateAIME(earnings []float64, salary float64, yearsWorked int) float64 {
-	var top35 []float64
-	if len(earnings) >= 35 {
-		top35 = earnings
-	} else if len(earnings) > 0 {
-		top35 = append([]float64{}, earnings...)
-		for len(top35) < 35 {
-			top35 = append(top35, 0)
+// earningsRecord returns the worker's earnings by calendar year: the history (from EarningsStartYear, or
+// ending last year when no start year is given) followed by projected earnings until StopWorkingAge.
+// Without a history or projection, EstimatedAnnualSalary is used for YearsWorked years.
+// Projected and estimated earnings count only up to the taxable maximum.
+func earningsRecord(input models.SocialSecurityCalculationInput, currentYear int, growth float64) map[int]float64 {
+	record := make(map[int]float64)
+	start := input.EarningsStartYear
+	if start == 0 {
+		start = currentYear - len(input.EarningsHistory)
+	}
+	for i, amount := range input.EarningsHistory {
+		record[start+i] += amount
+	}
+	if input.FutureAnnualEarnings > 0 && input.BirthYear > 0 {
+		first := max(start+len(input.EarningsHistory), currentYear)
+		for year := first; year < input.BirthYear+input.StopWorkingAge; year++ {
+			earnings := input.FutureAnnualEarnings * math.Pow(1+growth, float64(year-first))
+			record[year] = math.Min(record[year]+earnings, taxableMaximum(year, growth))
 		}
-	} else if salary > 0 && yearsWorked > 0 {
-		for i := 0; i < 35; i++ {
-			if i < yearsWorked {
-				top35 = append(top35, salary)
-			} else {
-				top35 = append(top35, 0)
-			}
+	}
+	if len(record) == 0 && input.EstimatedAnnualSalary > 0 {
+		// The salary is in today's wages, so each year is capped at today's taxable maximum
+		// (each year's base indexed to today)
+		salary := math.Min(input.EstimatedAnnualSalary, taxableMaximum(currentYear, growth))
+		for i := 1; i <= input.YearsWorked; i++ {
+			record[currentYear-i] = salary
 		}
-	} else {
-		return 0
 	}
-	// Sort and take top 35
+	return record
+}
+
+// estimateAIME indexes each year's earnings to the AWI of the indexing year (the year the worker turns 60),
+// takes later years at face value, and averages the highest 35 years over 420 months, rounded down to the dollar.
+// An indexing year of 0 treats the earnings as already indexed.
+func estimateAIME(record map[int]float64, indexingYear int, growth float64) float64 {
+	indexed := make([]float64, 0, len(record))
+	for year, amount := range record {
+		if indexingYear > 0 && year < indexingYear {
+			amount *= averageWage(indexingYear, growth) / averageWage(year, growth)
+		}
+		indexed = append(indexed, amount)
+	}
+	sort.Sort(sort.Reverse(sort.Float64Slice(indexed)))
 	sum := 0.0
-	for _, v := range top35 {
-		sum += v
+	for i := 0; i < len(indexed) && i < 35; i++ {
+		sum += indexed[i]
 	}
-	return sum / 420.0 // 35 years * 12 months
+	return math.Floor(sum / 420.0) // 35 years * 12 months
 }
 
-// Calculate PIA from AIME
-func calculatePIA(aime float64) float64 {
+// calculatePIA applies the 90/32/15 formula at the given bend points, rounded down to the dime.
+func calculatePIA(aime float64, bendPoints []float64) float64 {
 	var pia float64
-	if aime <= piaBendPoints[0] {
+	if aime <= bendPoints[0] {
 		pia = piaPercentages[0] * aime
-	} else if aime <= piaBendPoints[1] {
-		pia = piaPercentages[0]*piaBendPoints[0] + piaPercentages[1]*(aime-piaBendPoints[0])
+	} else if aime <= bendPoints[1] {
+		pia = piaPercentages[0]*bendPoints[0] + piaPercentages[1]*(aime-bendPoints[0])
 	} else {
-		pia = piaPercentages[0]*piaBendPoints[0] + piaPercentages[1]*(piaBendPoints[1]-piaBendPoints[0]) + piaPercentages[2]*(aime-piaBendPoints[1])
+		pia = piaPercentages[0]*bendPoints[0] + piaPercentages[1]*(bendPoints[1]-bendPoints[0]) + piaPercentages[2]*(aime-bendPoints[1])
+	}
+	return floorDime(pia)
+}
+
+// piaWithCOLAs raises the PIA by each December COLA from the eligibility year through the year before claimYear.
+func piaWithCOLAs(pia float64, eligibilityYear, claimYear int, assumed float64) float64 {
+	for year := eligibilityYear; year < claimYear; year++ {
+		pia = floorDime(pia * (1 + socialSecurityCOLA(year, assumed)))
 	}
 	return pia
 }
@@ -79,65 +223,236 @@ func calculatePIA(aime float64) float64 {
 // CalculateSocialSecurity projects SS benefits at 62, FRA, 70, and chosen claim age
 func CalculateSocialSecurity(input models.SocialSecurityCalculationInput) models.SocialSecurityCalculationResult {
 	var notes string
-	fra := getFRA(input.BirthYear)
+	fraYears, fraMonths := FullRetirementAge(input.BirthYear)
+	fra := fraYears*12 + fraMonths
+	claim := input.ClaimAge*12 + input.ClaimAgeMonths
+	if input.ClaimAge == 0 {
+		claim = fra
+	}
+	if claim < 62*12 {
+		notes += fmt.Sprintf("Claiming age %d is before 62, the earliest age for retirement benefits; benefits are computed from 62.\n", input.ClaimAge)
+		claim = 62 * 12
+		input.ClaimAge, input.ClaimAgeMonths = 62, 0
+	}
+	claimFactor := claimingFactor(claim, fra)
 
 	// Prefer user-provided SSA statement values if present
 	if input.UserProvidedEstimate62 > 0 && input.UserProvidedEstimateFRA > 0 && input.UserProvidedEstimate70 > 0 {
-		claimingAmount := 0.0
-		switch input.ClaimAge {
-		case 62:
+		// The statement's FRA amount is the PIA; other ages use the exact monthly factors
+		claimingAmount := input.UserProvidedEstimateFRA * claimFactor
+		switch claim {
+		case 62 * 12:
 			claimingAmount = input.UserProvidedEstimate62
-		case fra:
-			claimingAmount = input.UserProvidedEstimateFRA
-		case 70:
+		case 70 * 12:
 			claimingAmount = input.UserProvidedEstimate70
-		default:
-			// Linear interpolate between FRA and 70 or 62
-			if input.ClaimAge < fra {
-				claimingAmount = input.UserProvidedEstimate62 + (input.UserProvidedEstimateFRA-input.UserProvidedEstimate62)*float64(input.ClaimAge-62)/float64(fra-62)
-			} else {
-				claimingAmount = input.UserProvidedEstimateFRA + (input.UserProvidedEstimate70-input.UserProvidedEstimateFRA)*float64(input.ClaimAge-fra)/float64(70-fra)
-			}
 		}
-		notes = "Used user-provided SSA statement values."
-		return models.SocialSecurityCalculationResult{
-			EstimatedAt62:  input.UserProvidedEstimate62,
-			EstimatedAtFRA: input.UserProvidedEstimateFRA,
-			EstimatedAt70:  input.UserProvidedEstimate70,
-			ClaimingAge:    input.ClaimAge,
-			ClaimingAmount: claimingAmount,
-			Notes:          notes,
+		notes += "Used user-provided SSA statement values."
+		return withClaimAdjustments(input, models.SocialSecurityCalculationResult{
+			EstimatedAt62:     input.UserProvidedEstimate62,
+			EstimatedAtFRA:    input.UserProvidedEstimateFRA,
+			EstimatedAt70:     input.UserProvidedEstimate70,
+			FRAYears:          fraYears,
+			FRAMonths:         fraMonths,
+			ClaimingAge:       claim / 12,
+			ClaimingAgeMonths: claim % 12,
+			ClaimingFactor:    claimFactor,
+			ClaimingAmount:    claimingAmount,
+			Notes:             notes,
+		})
+	}
+
+	// Otherwise, compute the PIA from the wage-indexed earnings record
+	growth := input.WageGrowthRate
+	if growth == 0 {
+		growth = defaultWageGrowth
+	}
+	currentYear := latestEarningsLimitYear
+	if input.BirthYear > 0 && input.CurrentAge > 0 {
+		currentYear = input.BirthYear + input.CurrentAge
+	}
+	salaryOnly := len(input.EarningsHistory) == 0 && input.FutureAnnualEarnings == 0 && input.EstimatedAnnualSalary > 0 && input.YearsWorked > 0
+	eligibilityYear, indexingYear := 0, 0
+	bendPoints := piaBendPoints(currentYear, growth)
+	if input.BirthYear > 0 {
+		eligibilityYear = input.BirthYear + 62
+		indexingYear = input.BirthYear + 60
+		bendPoints = piaBendPoints(eligibilityYear, growth)
+	}
+	record := earningsRecord(input, currentYear, growth)
+	if salaryOnly {
+		// An average salary is taken to be in today's wages already
+		indexingYear = 0
+	}
+	aime := estimateAIME(record, indexingYear, growth)
+	pia := calculatePIA(aime, bendPoints)
+
+	// Benefits at each age start from the PIA with COLAs received since 62
+	benefitAt := func(months int) float64 {
+		if eligibilityYear == 0 {
+			return pia * claimingFactor(months, fra)
 		}
+		return piaWithCOLAs(pia, eligibilityYear, input.BirthYear+months/12, input.COLARate) * claimingFactor(months, fra)
+	}
+	est62 := benefitAt(62 * 12)
+	estFRA := benefitAt(fra)
+	est70 := benefitAt(70 * 12)
+	claimingAmount := benefitAt(claim)
+	if aime > 0 {
+		notes += fmt.Sprintf("AIME $%.0f from the highest 35 of %d years of earnings; PIA $%.2f at bend points $%.0f/$%.0f.\n", aime, len(record), pia, bendPoints[0], bendPoints[1])
 	}
 
-	// Otherwise, estimate from salary/history
-	aime := estimateAIME(input.EarningsHistory, input.EstimatedAnnualSalary, input.YearsWorked)
-	pia := calculatePIA(aime)
+	if aime == 0 || pia == 0 {
+		notes += "No SSA statement or sufficient earnings data provided; estimate is zero."
+	}
+	if salaryOnly {
+		notes += "Estimate based on average salary and years worked."
+	}
 
-	// Early/late claiming factors
-	factor62 := claimingFactor(62, fra)
-	factorFRA := 1.0
-	factor70 := claimingFactor(70, fra)
-	claimingFactorX := claimingFactor(input.ClaimAge, fra)
+	return withClaimAdjustments(input, models.SocialSecurityCalculationResult{
+		EstimatedAt62:     est62,
+		EstimatedAtFRA:    estFRA,
+		EstimatedAt70:     est70,
+		FRAYears:          fraYears,
+		FRAMonths:         fraMonths,
+		ClaimingAge:       claim / 12,
+		ClaimingAgeMonths: claim % 12,
+		ClaimingFactor:    claimFactor,
+		ClaimingAmount:    claimingAmount,
+		AIME:              aime,
+		PIA:               pia,
+		BendPoints:        bendPoints,
+		EligibilityYear:   eligibilityYear,
+		Notes:             notes,
+	})
+}
+
+// withClaimAdjustments applies the earnings test to the claiming amount when yearly earnings are given, and
+// any trust fund shortfall to the amount payable.
+func withClaimAdjustments(input models.SocialSecurityCalculationInput, result models.SocialSecurityCalculationResult) models.SocialSecurityCalculationResult {
+	result.PayableAmount = result.ClaimingAmount
+	if input.TrustFund.DepletionYear > 0 && result.ClaimingAmount > 0 {
+		claimYear := SocialSecurityClaimYear(input, result)
+		share := TrustFundPayableShare(input.TrustFund, max(claimYear, input.TrustFund.DepletionYear), input.BirthYear)
+		result.PayableAmount = result.ClaimingAmount * share
+		result.PayableFromYear = max(claimYear, input.TrustFund.DepletionYear)
+		if result.Notes != "" && !strings.HasSuffix(result.Notes, "\n") {
+			result.Notes += "\n"
+		}
+		result.Notes += fmt.Sprintf("Trust fund shortfall: %.0f%% of scheduled benefits payable from %d ($%.2f/month).\n", share*100, result.PayableFromYear, result.PayableAmount)
+	}
+	if len(input.YearlyEarnedIncome) == 0 {
+		return result
+	}
+	result.EarningsTest = CalculateSSEarningsTest(models.SSEarningsTestInput{
+		BirthYear:               input.BirthYear,
+		BirthMonth:              input.BirthMonth,
+		ClaimAge:                input.ClaimAge,
+		ClaimAgeMonths:          input.ClaimAgeMonths,
+		MonthlyBenefit:          result.ClaimingAmount,
+		COLARate:                input.COLARate,
+		YearlyEarnedIncome:      input.YearlyEarnedIncome,
+		EarningsLimitGrowthRate: input.EarningsLimitGrowthRate,
+	})
+	if result.EarningsTest.Notes != "" {
+		if result.Notes != "" && !strings.HasSuffix(result.Notes, "\n") {
+			result.Notes += "\n"
+		}
+		result.Notes += result.EarningsTest.Notes
+	}
+	return result
+}
 
-	est62 := pia * factor62
-	estFRA := pia * factorFRA
-	est70 := pia * factor70
-	claimingAmount := pia * claimingFactorX
+// CalculateSSEarningsTest withholds benefits claimed before FRA for earnings over the annual limit: $1 for every $2,
+// or $1 for every $3 of earnings before the FRA month in the year FRA is reached. Each year is tested against the
+// benefit with the COLAs paid since the claiming year. At FRA the reduction factor is recomputed as if benefits
+// had started later by the number of months withheld.
+func CalculateSSEarningsTest(input models.SSEarningsTestInput) models.SSEarningsTestResult {
+	var notes string
+	fraYears, fraMonths := FullRetirementAge(input.BirthYear)
+	fra := fraYears*12 + fraMonths
+	claim := input.ClaimAge*12 + input.ClaimAgeMonths
+	if input.ClaimAge == 0 {
+		claim = fra
+	}
+	claim = max(claim, 62*12)
+	factor := claimingFactor(claim, fra)
+	result := models.SSEarningsTestResult{
+		ClaimingFactor:         factor,
+		RecomputedFactor:       factor,
+		MonthlyBenefitAfterFRA: input.MonthlyBenefit,
+	}
+	if claim >= fra || input.MonthlyBenefit <= 0 {
+		return result
+	}
 
-	if aime == 0 || pia == 0 {
-		notes = "No SSA statement or sufficient earnings data provided; estimate is zero."
+	// Months are counted as year*12 + (month-1)
+	born := input.BirthYear*12 + max(input.BirthMonth, 1) - 1
+	start, fraMonth := born+claim, born+fra
+	for year := start / 12; year*12 < fraMonth; year++ {
+		first := max(start, year*12)
+		last := min(fraMonth-1, year*12+11)
+		months := last - first + 1
+		monthly := input.MonthlyBenefit * math.Pow(1+input.COLARate, float64(year-start/12))
+		benefit := monthly * float64(months)
+		earned := 0.0
+		if i := year - start/12; i < len(input.YearlyEarnedIncome) {
+			earned = input.YearlyEarnedIncome[i]
+		}
+		var limit, withheld float64
+		if year == fraMonth/12 {
+			// Only earnings before the FRA month count in the year FRA is reached
+			earned *= float64(fraMonth-year*12) / 12.0
+			limit = fraYearEarningsTestLimit(year, input.EarningsLimitGrowthRate)
+			withheld = math.Max(earned-limit, 0) / 3.0
+		} else {
+			limit = earningsTestLimit(year, input.EarningsLimitGrowthRate)
+			withheld = math.Max(earned-limit, 0) / 2.0
+		}
+		withheld = math.Min(withheld, benefit)
+		monthsWithheld := min(int(math.Ceil(withheld/monthly-1e-9)), months)
+		result.Years = append(result.Years, models.SSEarningsTestYear{
+			Year:           year,
+			Months:         months,
+			Benefit:        benefit,
+			EarnedIncome:   earned,
+			EarningsLimit:  limit,
+			Withheld:       withheld,
+			MonthsWithheld: monthsWithheld,
+		})
+		result.TotalWithheld += withheld
+		result.MonthsWithheld += monthsWithheld
 	}
-	if len(input.EarningsHistory) == 0 && input.EstimatedAnnualSalary > 0 && input.YearsWorked > 0 {
-		notes = "Estimate based on average salary and years worked."
+
+	if result.MonthsWithheld > 0 {
+		result.RecomputedFactor = claimingFactor(min(claim+result.MonthsWithheld, fra), fra)
+		result.MonthlyBenefitAfterFRA = input.MonthlyBenefit * result.RecomputedFactor / factor
+		notes += fmt.Sprintf("Earnings test withholds $%.2f (%d months) before FRA; at FRA the benefit is recomputed to $%.2f/month.\n",
+			result.TotalWithheld, result.MonthsWithheld, result.MonthlyBenefitAfterFRA)
 	}
+	result.Notes = notes
+	return result
+}
+
+// SocialSecurityClaimYear returns the calendar year benefits start at the result's claiming age.
+func SocialSecurityClaimYear(input models.SocialSecurityCalculationInput, result models.SocialSecurityCalculationResult) int {
+	return input.BirthYear + (max(input.BirthMonth, 1)-1+result.ClaimingAge*12+result.ClaimingAgeMonths)/12
+}
+
+// Share of scheduled benefits payable after trust fund depletion when the policy gives none
+const defaultTrustFundPayableShare = 0.77
 
-	return models.SocialSecurityCalculationResult{
-		EstimatedAt62:  est62,
-		EstimatedAtFRA: estFRA,
-		EstimatedAt70:  est70,
-		ClaimingAge:    input.ClaimAge,
-		ClaimingAmount: claimingAmount,
-		Notes:          notes,
+// TrustFundPayableShare returns the share of scheduled benefits payable in a year to someone born in birthYear.
+func TrustFundPayableShare(policy models.TrustFundPolicy, year, birthYear int) float64 {
+	if policy.DepletionYear == 0 || year < policy.DepletionYear {
+		return 1.0
+	}
+	if policy.Mode == "cohort" {
+		if share, ok := policy.CohortShares[birthYear]; ok {
+			return share
+		}
+	}
+	if policy.PayableShare == 0 {
+		return defaultTrustFundPayableShare
 	}
+	return policy.PayableShare
 }
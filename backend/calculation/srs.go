@@ -6,8 +6,45 @@ import (
 	"math"
 )
 
-// Earnings test threshold for 2025; adjust as needed for future years
-const earningsTestLimit2025 = 23400.0
+// Social Security annual earnings test limits for those under full retirement age, by year
+var earningsTestLimits = map[int]float64{
+	2015: 15720, 2016: 15720, 2017: 16920, 2018: 17040, 2019: 17640,
+	2020: 18240, 2021: 18960, 2022: 19560, 2023: 21240, 2024: 22320, 2025: 23400,
+}
+
+// Higher earnings test limit for the year of reaching full retirement age (earnings before the FRA month), by year
+var fraYearEarningsTestLimits = map[int]float64{
+	2015: 41880, 2016: 41880, 2017: 44880, 2018: 45360, 2019: 46920,
+	2020: 48600, 2021: 50520, 2022: 51960, 2023: 56520, 2024: 59520, 2025: 62160,
+}
+
+// Latest year with a published earnings test limit
+const latestEarningsLimitYear = 2025
+
+// earningsTestLimit returns the year's earnings test limit, projecting later years with wage growth
+// and rounding to a multiple of $120 as SSA does ($10 a month). Year 0 uses the latest published limit.
+func earningsTestLimit(year int, growth float64) float64 {
+	return publishedOrProjectedLimit(earningsTestLimits, year, growth)
+}
+
+// fraYearEarningsTestLimit returns the limit for the year of reaching full retirement age, projected the same way.
+func fraYearEarningsTestLimit(year int, growth float64) float64 {
+	return publishedOrProjectedLimit(fraYearEarningsTestLimits, year, growth)
+}
+
+func publishedOrProjectedLimit(limits map[int]float64, year int, growth float64) float64 {
+	if year == 0 {
+		year = latestEarningsLimitYear
+	}
+	if limit, ok := limits[year]; ok {
+		return limit
+	}
+	if year < 2015 {
+		return limits[2015]
+	}
+	projected := limits[latestEarningsLimitYear] * math.Pow(1+growth, float64(year-latestEarningsLimitYear))
+	return math.Round(projected/120) * 120
+}
 
 // CalculateSRS computes the FERS Annuity Supplement (SRS) based on user input.
 func CalculateSRS(input models.SRSCalculationInput) models.SRSCalculationResult {
@@ -15,7 +52,16 @@ func CalculateSRS(input models.SRSCalculationInput) models.SRSCalculationResult
 	isEligible := false
 
 	// Eligibility: Must be immediate, unreduced annuity (not MRA+10, deferred, or disability), and retire before 62
-	if input.IsImmediateUnreducedAnnuity && input.RetirementAge < 62 {
+	immediateUnreduced := input.IsImmediateUnreducedAnnuity
+	switch input.RetirementType {
+	case "":
+		// Use the caller's flag
+	case "MRA+30", "60+20", "VERA", "DSR":
+		immediateUnreduced = true
+	default:
+		immediateUnreduced = false
+	}
+	if immediateUnreduced && input.RetirementAge < 62 {
 		isEligible = true
 	} else {
 		notes += "Not eligible for SRS (must be immediate, unreduced FERS annuity and retire before age 62).\n"
@@ -36,10 +82,9 @@ func CalculateSRS(input models.SRSCalculationInput) models.SRSCalculationResult
 	yearsOfFERSService := math.Ceil(input.YearsOfFERSService)
 	srsAmount := (input.EstimatedSocialSecurityAt62 / 40.0) * yearsOfFERSService
 
-	// Earnings test: $1 reduction for every $2 earned above the limit
-	// Use 2025 limit for now; in production, this should be dynamic
-	earningsTestLimit := earningsTestLimit2025
-	overLimit := input.ProjectedEarnedIncome - earningsTestLimit
+	// Earnings test: $1 reduction for every $2 earned above the retirement year's limit
+	limit := earningsTestLimit(input.RetirementYear, input.EarningsLimitGrowthRate)
+	overLimit := input.ProjectedEarnedIncome - limit
 	earningsReduction := 0.0
 	if overLimit > 0 {
 		earningsReduction = overLimit / 2.0
@@ -54,11 +99,81 @@ func CalculateSRS(input models.SRSCalculationInput) models.SRSCalculationResult
 		finalSRS = 0
 	}
 
+	yearly, yearlyNotes := srsSchedule(input, srsAmount)
+	notes += yearlyNotes
+
 	return models.SRSCalculationResult{
 		AnnualSRSAmount:       finalSRS,
 		MonthlySRSAmount:      finalSRS / 12.0,
 		EarningsTestReduction: earningsReduction,
 		IsEligible:            true,
+		YearlySRS:             yearly,
 		Notes:                 notes,
 	}
 }
+
+// srsSchedule pays the supplement month by month from the month after retirement (or after MRA for VERA/DSR)
+// through the month the retiree turns 62, with no COLA, applying each year's earnings test.
+func srsSchedule(input models.SRSCalculationInput, annualAmount float64) ([]models.SRSYear, string) {
+	if input.BirthYear == 0 || input.RetirementYear == 0 {
+		return nil, ""
+	}
+	var notes string
+	birthMonth := input.BirthMonth
+	if birthMonth == 0 {
+		birthMonth = 1
+	}
+	// Months are counted as year*12 + (month-1)
+	start := input.RetirementYear * 12
+	if input.RetirementMonth > 0 {
+		start += input.RetirementMonth
+	}
+	mraYears, mraMonths := GetMRA(input.BirthYear)
+	mra := input.BirthYear*12 + birthMonth - 1 + mraYears*12 + mraMonths
+	if (input.RetirementType == "VERA" || input.RetirementType == "DSR") && start <= mra {
+		start = mra + 1
+		notes += fmt.Sprintf("SRS deferred to MRA: first payment %d-%02d.\n", start/12, start%12+1)
+	}
+	end := (input.BirthYear+62)*12 + birthMonth - 1
+
+	var years []models.SRSYear
+	for year := start / 12; year <= end/12; year++ {
+		first := max(start, year*12)
+		last := min(end, year*12+11)
+		if last < first {
+			continue
+		}
+		months := last - first + 1
+		amount := annualAmount / 12.0 * float64(months)
+
+		earned := input.ProjectedEarnedIncome
+		if len(input.YearlyEarnedIncome) > 0 {
+			earned = 0
+			if i := year - input.RetirementYear; i >= 0 && i < len(input.YearlyEarnedIncome) {
+				earned = input.YearlyEarnedIncome[i]
+			}
+		}
+		// Special-category retirees are exempt from the earnings test until MRA
+		if input.IsSpecialCategory {
+			switch {
+			case year*12+11 < mra:
+				earned = 0
+			case year*12 < mra:
+				earned *= float64(year*12+12-mra) / 12.0
+			}
+		}
+		limit := earningsTestLimit(year, input.EarningsLimitGrowthRate)
+		reduction := math.Min(math.Max(earned-limit, 0)/2.0, amount)
+		years = append(years, models.SRSYear{
+			Year:                  year,
+			Age:                   year - input.BirthYear,
+			Months:                months,
+			Amount:                amount,
+			EarnedIncome:          earned,
+			EarningsLimit:         limit,
+			EarningsTestReduction: reduction,
+			Paid:                  amount - reduction,
+		})
+	}
+	return years, notes
+}
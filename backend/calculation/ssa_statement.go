@@ -0,0 +1,118 @@
+package calculation
+
+import (
+	"encoding/xml"
+	"ferex/backend/models"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ssaStatementXML mirrors the "my Social Security" statement download. Element names are matched
+// without their namespace so the osss schema versions all parse the same way.
+type ssaStatementXML struct {
+	XMLName  xml.Name `xml:"OnlineSocialSecurityStatementData"`
+	UserInfo struct {
+		Name        string `xml:"Name"`
+		DateOfBirth string `xml:"DateOfBirth"`
+	} `xml:"UserInformation"`
+	EstimatedBenefits struct {
+		EarlyRetirement   ssaEstimateXML `xml:"EarlyRetirement"`
+		FullRetirement    ssaEstimateXML `xml:"FullRetirement"`
+		DelayedRetirement ssaEstimateXML `xml:"DelayedRetirement"`
+	} `xml:"EstimatedBenefits"`
+	Earnings []struct {
+		StartYear        int     `xml:"startYear,attr"`
+		EndYear          int     `xml:"endYear,attr"`
+		FicaEarnings     float64 `xml:"FicaEarnings"`
+		MedicareEarnings float64 `xml:"MedicareEarnings"`
+	} `xml:"EarningsRecord>Earnings"`
+}
+
+type ssaEstimateXML struct {
+	EstimatedBenefitAmount float64 `xml:"EstimatedBenefitAmount"`
+}
+
+// ImportSSAStatement parses an SSA earnings record XML file into Social Security calculation input.
+// Years in the file are kept with their earnings; the history passed on starts at the first listed year
+// and fills gaps with zeros. Years SSA has not yet posted (-1) are left out of the history.
+func ImportSSAStatement(data []byte) (models.SSAStatementImportResult, error) {
+	var statement ssaStatementXML
+	if err := xml.Unmarshal(data, &statement); err != nil {
+		return models.SSAStatementImportResult{}, fmt.Errorf("not an SSA earnings record: %v", err)
+	}
+
+	var result models.SSAStatementImportResult
+	var notes string
+	if dob, err := time.Parse("2006-01-02", statement.UserInfo.DateOfBirth); err == nil {
+		result.Input.BirthYear = dob.Year()
+		result.Input.BirthMonth = int(dob.Month())
+	} else if statement.UserInfo.DateOfBirth != "" {
+		notes += fmt.Sprintf("Unrecognized date of birth %q.\n", statement.UserInfo.DateOfBirth)
+	}
+
+	posted := make(map[int]float64)
+	for _, e := range statement.Earnings {
+		if e.EndYear == 0 {
+			e.EndYear = e.StartYear
+		}
+		if e.EndYear != e.StartYear {
+			// Early records (1937-1950) are reported as a lump over several years and are not indexed
+			notes += fmt.Sprintf("Earnings of $%.2f for %d-%d are reported as a total and were skipped.\n", e.FicaEarnings, e.StartYear, e.EndYear)
+			continue
+		}
+		year := models.SSAEarningsYear{
+			Year:             e.StartYear,
+			FICAEarnings:     e.FicaEarnings,
+			MedicareEarnings: e.MedicareEarnings,
+			Posted:           e.FicaEarnings >= 0,
+		}
+		if !year.Posted {
+			year.FICAEarnings = 0
+			year.MedicareEarnings = max(year.MedicareEarnings, 0)
+			result.UnpostedYears = append(result.UnpostedYears, year.Year)
+		} else {
+			posted[year.Year] += year.FICAEarnings
+		}
+		result.Earnings = append(result.Earnings, year)
+	}
+	sort.Slice(result.Earnings, func(i, j int) bool { return result.Earnings[i].Year < result.Earnings[j].Year })
+	sort.Ints(result.UnpostedYears)
+
+	if len(posted) > 0 {
+		first, last := 0, 0
+		for year := range posted {
+			if first == 0 || year < first {
+				first = year
+			}
+			last = max(last, year)
+		}
+		result.Input.EarningsStartYear = first
+		for year := first; year <= last; year++ {
+			amount, ok := posted[year]
+			if !ok || amount == 0 {
+				result.GapYears = append(result.GapYears, year)
+			}
+			result.Input.EarningsHistory = append(result.Input.EarningsHistory, amount)
+		}
+		notes += fmt.Sprintf("Imported %d years of earnings (%d-%d).\n", last-first+1, first, last)
+	} else {
+		notes += "No posted earnings found in the file.\n"
+	}
+	if len(result.GapYears) > 0 {
+		notes += fmt.Sprintf("No earnings recorded for %v; check these years with SSA.\n", result.GapYears)
+	}
+	if len(result.UnpostedYears) > 0 {
+		notes += fmt.Sprintf("Earnings not yet posted for %v.\n", result.UnpostedYears)
+	}
+
+	result.Input.UserProvidedEstimate62 = statement.EstimatedBenefits.EarlyRetirement.EstimatedBenefitAmount
+	result.Input.UserProvidedEstimateFRA = statement.EstimatedBenefits.FullRetirement.EstimatedBenefitAmount
+	result.Input.UserProvidedEstimate70 = statement.EstimatedBenefits.DelayedRetirement.EstimatedBenefitAmount
+	if result.Input.UserProvidedEstimateFRA == 0 {
+		notes += "The file has no benefit estimates; benefits will be computed from the earnings record.\n"
+	}
+
+	result.Notes = notes
+	return result, nil
+}
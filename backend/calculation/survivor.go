@@ -2,29 +2,100 @@ package calculation
 
 import (
 	"ferex/backend/models"
+	"fmt"
+	"math"
 )
 
-// getSurvivorReduction returns the reduction and survivor percent based on pension type and election
-func getSurvivorReduction(pensionType, election string) (reduction, survivorPct float64, notes string) {
+// csrsSurvivorCost returns the OPM CSRS survivor reduction: 2.5% of the first $3,600 of the base plus 10% of the rest.
+func csrsSurvivorCost(base float64) float64 {
+	if base <= 0 {
+		return 0
+	}
+	return math.Min(base, 3600)*0.025 + math.Max(base-3600, 0)*0.10
+}
+
+// insurableInterestReduction returns OPM's insurable interest reduction for a beneficiary the given number of years younger than the retiree.
+func insurableInterestReduction(yearsYounger int) float64 {
+	switch {
+	case yearsYounger < 5:
+		return 0.10
+	case yearsYounger < 10:
+		return 0.15
+	case yearsYounger < 15:
+		return 0.20
+	case yearsYounger < 20:
+		return 0.25
+	case yearsYounger < 25:
+		return 0.30
+	case yearsYounger < 30:
+		return 0.35
+	default:
+		return 0.40
+	}
+}
+
+// unknownBeneficiaryAge is the age difference passed for an insurable interest beneficiary whose age was not given.
+const unknownBeneficiaryAge = math.MinInt
+
+// beneficiaryYearsYounger returns how many years younger the beneficiary is than the retiree (unknownBeneficiaryAge when the beneficiary's age is not given).
+func beneficiaryYearsYounger(retireeAge, beneficiaryAge int) int {
+	if beneficiaryAge <= 0 {
+		return unknownBeneficiaryAge
+	}
+	return retireeAge - beneficiaryAge
+}
+
+// getSurvivorReduction returns the annual reduction and survivor annuity for an election.
+// base is the CSRS partial-election base amount; it is ignored for FERS and max elections.
+// yearsYounger is the insurable interest beneficiary's age difference; it is ignored for spouse elections.
+func getSurvivorReduction(pensionType, election string, annuity, base float64, yearsYounger int) (reduction, survivorAnnuity float64, notes string) {
+	if election == "insurable interest" {
+		survivorPct := 0.55
+		if pensionType == "FERS" {
+			survivorPct = 0.50
+		} else if pensionType != "CSRS" && pensionType != "CSRSOffset" {
+			return 0.0, 0.0, "Unknown pension type"
+		}
+		// The reduction depends on the age difference, so it is not guessed
+		if yearsYounger == unknownBeneficiaryAge {
+			return 0.0, 0.0, fmt.Sprintf("%s insurable interest: the beneficiary's age is required; no reduction applied", pensionType)
+		}
+		rate := insurableInterestReduction(yearsYounger)
+		reduction = annuity * rate
+		survivorAnnuity = (annuity - reduction) * survivorPct
+		return reduction, survivorAnnuity, fmt.Sprintf("%s insurable interest: beneficiary %d years younger, %.0f%% reduction; %.0f%% of the reduced annuity to survivor", pensionType, yearsYounger, rate*100, survivorPct*100)
+	}
 	switch pensionType {
 	case "FERS":
 		switch election {
 		case "max":
-			return 0.10, 0.50, "FERS max: 50% to survivor, 10% reduction"
+			reduction = annuity * 0.10
+			return reduction, annuity * 0.50, "FERS max: 50% to survivor, 10% reduction"
 		case "partial":
-			return 0.05, 0.25, "FERS partial: 25% to survivor, 5% reduction"
+			reduction = annuity * 0.05
+			return reduction, annuity * 0.25, "FERS partial: 25% to survivor, 5% reduction"
 		default:
 			return 0.0, 0.0, "FERS: No survivor benefit elected"
 		}
 	case "CSRS", "CSRSOffset":
 		switch election {
 		case "max":
-			return 0.10, 0.55, "CSRS max: 55% to survivor, ~10% reduction"
+			base = annuity
+			notes = "CSRS max"
 		case "partial":
-			return 0.05, 0.50, "CSRS partial: 50% to survivor, 5% reduction"
+			if base <= 0 {
+				base = annuity / 2
+				notes = "CSRS partial (no base amount given; half the annuity assumed)"
+			} else {
+				base = math.Min(base, annuity)
+				notes = "CSRS partial"
+			}
 		default:
 			return 0.0, 0.0, "CSRS: No survivor benefit elected"
 		}
+		reduction = csrsSurvivorCost(base)
+		survivorAnnuity = base * 0.55
+		return reduction, survivorAnnuity, fmt.Sprintf("%s: 55%% of $%.2f base to survivor, $%.2f reduction (2.5%% of first $3,600 + 10%% of remainder)", notes, base, reduction)
 	default:
 		return 0.0, 0.0, "Unknown pension type"
 	}
@@ -32,9 +103,26 @@ func getSurvivorReduction(pensionType, election string) (reduction, survivorPct
 
 // CalculateSurvivorBenefit projects survivor annuity/income
 func CalculateSurvivorBenefit(input models.SurvivorBenefitCalculationInput) models.SurvivorBenefitCalculationResult {
-	reduction, survivorPct, notes := getSurvivorReduction(input.PensionType, input.SurvivorElection)
-	baseAnnuity := input.InitialAnnuity * (1 - reduction)
-	initialSurvivor := baseAnnuity * survivorPct
+	reduction, initialSurvivor, notes := getSurvivorReduction(input.PensionType, input.SurvivorElection, input.InitialAnnuity, input.SurvivorBaseAmount, input.BeneficiaryYearsYounger)
+	ssSurvivor := input.SSSurvivorAmount
+	// Household benefits are scheduled; a trust fund shortfall cuts them from the depletion year
+	var trustFund models.TrustFundPolicy
+	ssFirstYear, spouseBirthYear := 0, 0
+	if input.IncludeSSSurvivor && ssSurvivor == 0 && input.SocialSecurity != nil {
+		household := *input.SocialSecurity
+		if household.Worker.DeathAge == 0 {
+			household.Worker.DeathAge = input.RetireeAgeAtDeath
+		}
+		hh := CalculateHouseholdSocialSecurity(household)
+		ssSurvivor = max(hh.Spouse.OwnBenefit, hh.Spouse.SurvivorBenefit) * 12
+		trustFund = household.TrustFund
+		ssFirstYear = household.Worker.BirthYear + household.Worker.DeathAge + 1
+		spouseBirthYear = household.Spouse.BirthYear
+		notes += fmt.Sprintf("; SS survivor benefit $%.2f/yr from household Social Security", ssSurvivor)
+		if trustFund.DepletionYear > 0 {
+			notes += fmt.Sprintf(", cut to the payable share from %d", max(ssFirstYear, trustFund.DepletionYear))
+		}
+	}
 	projected := make([]float64, input.YearsToProject)
 	current := initialSurvivor
 	total := 0.0
@@ -45,7 +133,7 @@ func CalculateSurvivorBenefit(input models.SurvivorBenefitCalculationInput) mode
 		ann := current
 		// Add SS survivor if included
 		if input.IncludeSSSurvivor {
-			ann += input.SSSurvivorAmount
+			ann += ssSurvivor * TrustFundPayableShare(trustFund, ssFirstYear+i, spouseBirthYear)
 		}
 		// Add TSP if included (simple: spread evenly over projection)
 		if input.IncludeTSP && input.TSPBalanceAtDeath > 0 && input.YearsToProject > 0 {
@@ -59,6 +147,8 @@ func CalculateSurvivorBenefit(input models.SurvivorBenefitCalculationInput) mode
 		total += ann
 	}
 	return models.SurvivorBenefitCalculationResult{
+		ReducedAnnuity:         input.InitialAnnuity - reduction,
+		SurvivorReduction:      reduction,
 		InitialSurvivorAnnuity: initialSurvivor,
 		ProjectedAnnuities:     projected,
 		TotalSurvivorIncome:    total,
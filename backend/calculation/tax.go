@@ -3,6 +3,7 @@ package calculation
 import (
 	"ferex/backend/models"
 	"fmt"
+	"math"
 )
 
 // Simplified 2025 federal tax brackets (for demonstration)
@@ -59,7 +60,17 @@ func CalculateTax(input models.TaxCalculationInput) models.TaxCalculationResult
 
 	// Social Security taxability (simplified): up to 85% taxable
 	// Provisional income = AGI + 0.5*SS + tax-exempt interest (ignored here)
-	agi := input.TaxablePension + input.TSPWithdrawal + input.OtherTaxableIncome
+	taxablePension := input.TaxablePension
+	if taxablePension == 0 && input.GrossPension > 0 {
+		taxablePension = math.Max(input.GrossPension-input.AnnuityTaxFree-input.FormerSpouseAward, 0)
+		if input.AnnuityTaxFree > 0 {
+			notes += fmt.Sprintf("Taxable pension: $%.2f after $%.2f tax-free recovery of contributions.\n", taxablePension, input.AnnuityTaxFree)
+		}
+		if input.FormerSpouseAward > 0 {
+			notes += fmt.Sprintf("Former spouse award of $%.2f is taxed to the former spouse.\n", input.FormerSpouseAward)
+		}
+	}
+	agi := taxablePension + input.TSPWithdrawal + input.OtherTaxableIncome
 	provisional := agi + 0.5*input.SocialSecurity
 	ssTaxable := 0.0
 	if provisional > 34000 && input.FilingStatus == "married" {
@@ -0,0 +1,177 @@
+package calculation
+
+import (
+	"ferex/backend/models"
+	"fmt"
+	"math"
+	"time"
+)
+
+// csrsComponentAnnuity applies the CSRS tiered formula and the 80% cap.
+func csrsComponentAnnuity(high3, years float64) (annuity float64, capped bool) {
+	first5 := math.Min(years, 5)
+	next5 := math.Min(math.Max(years-5, 0), 5)
+	over10 := math.Max(years-10, 0)
+	annuity = high3 * (first5*0.015 + next5*0.0175 + over10*0.02)
+	if annuity > high3*0.8 {
+		return high3 * 0.8, true
+	}
+	return annuity, false
+}
+
+// projectComponentCOLA projects a component annuity; FERS components get the capped COLA and only from age 62.
+func projectComponentCOLA(amount, cpi float64, system string, retirementAge, years int) []float64 {
+	amounts := make([]float64, years)
+	current := amount
+	for i := 0; i < years; i++ {
+		if i > 0 {
+			rate := cpi
+			if system == "FERS" {
+				rate = 0
+				if retirementAge+i > 62 {
+					rate = getFERSCOLA(cpi*100) / 100.0
+				}
+			}
+			current *= 1 + rate
+		}
+		amounts[i] = current
+	}
+	return amounts
+}
+
+// splitAtTransfer divides a service history into the periods before the FERS election (CSRS) and from it (FERS).
+func splitAtTransfer(history []models.ServicePeriod, transfer time.Time) (csrsPeriods, fersPeriods []models.ServicePeriod) {
+	for _, p := range history {
+		switch {
+		case p.EndDate.Before(transfer):
+			csrsPeriods = append(csrsPeriods, p)
+		case !p.StartDate.Before(transfer):
+			fersPeriods = append(fersPeriods, p)
+		default:
+			before, after := p, p
+			before.EndDate = transfer.AddDate(0, 0, -1)
+			after.StartDate = transfer
+			csrsPeriods = append(csrsPeriods, before)
+			fersPeriods = append(fersPeriods, after)
+		}
+	}
+	return csrsPeriods, fersPeriods
+}
+
+// CalculateFERSTransferee computes the CSRS and FERS components of a transferee's annuity.
+func CalculateFERSTransferee(input models.FERSTransfereeCalculationInput) models.FERSTransfereeCalculationResult {
+	var notes string
+	csrsService, fersService := input.CSRSYearsOfService, input.FERSYearsOfService
+	totalService := csrsService + fersService
+	csrsFactor, fersFactor := 1.0, 1.0
+	if len(input.ServiceHistory) > 0 {
+		if input.TransferDate.IsZero() {
+			return models.FERSTransfereeCalculationResult{Notes: "A FERS transfer date is required to divide the service history between the components.\n"}
+		}
+		csrsPeriods, fersPeriods := splitAtTransfer(input.ServiceHistory, input.TransferDate)
+		var csrsEligibility, fersEligibility float64
+		var csrsPartTime, fersPartTime bool
+		var csrsNotes, fersNotes string
+		csrsService, csrsEligibility, csrsPartTime, csrsFactor, csrsNotes = resolveService("CSRS", csrsPeriods, 0, false, 1.0)
+		fersService, fersEligibility, fersPartTime, fersFactor, fersNotes = resolveService("FERS", fersPeriods, 0, false, 1.0)
+		if !csrsPartTime {
+			csrsFactor = 1.0
+		}
+		if !fersPartTime {
+			fersFactor = 1.0
+		}
+		notes += csrsNotes + fersNotes
+		notes += fmt.Sprintf("Service before %s is CSRS (%.2f years), from it FERS (%.2f years).\n", input.TransferDate.Format("2006-01-02"), csrsService, fersService)
+		totalService = csrsEligibility + fersEligibility
+	}
+
+	eligibility := DetermineFERSEligibility(models.FERSEligibilityInput{
+		BirthYear:        input.BirthYear,
+		BirthMonth:       input.BirthMonth,
+		AgeAtRetirement:  input.AgeAtRetirement,
+		YearsOfService:   totalService,
+		RetirementOption: input.RetirementOption,
+		AnnuityStartAge:  input.AnnuityStartAge,
+	})
+	notes += fmt.Sprintf("Retirement type: %s\n", eligibility.RetirementType)
+	notes += eligibility.Notes
+	if !eligibility.IsEligible {
+		return models.FERSTransfereeCalculationResult{
+			RetirementType: eligibility.RetirementType,
+			Notes:          notes,
+		}
+	}
+
+	// CSRS component: tiered formula on CSRS service plus sick leave
+	sickLeave, sickNotes := sickLeaveCredit(input.UnusedSickLeaveHours, input.SickLeaveSchedule, input.SickLeaveHoursPerWeek, input.UnusedSickLeaveMonths)
+	notes += sickNotes
+	csrsYears := csrsService + durationYears(sickLeave)
+	csrsGross, capped := csrsComponentAnnuity(input.High3Salary, csrsYears)
+	if capped {
+		notes += "80% High-3 maximum applied to CSRS component.\n"
+	}
+	csrsGross *= csrsFactor
+
+	// FERS component: 1.0% (or 1.1% at 62 with 20 years total service) on FERS service
+	multiplier := 0.01
+	if eligibility.AppliesHigherFactor {
+		multiplier = 0.011
+	}
+	fersGross := input.High3Salary * fersService * multiplier * fersFactor
+	if csrsFactor < 1 || fersFactor < 1 {
+		notes += fmt.Sprintf("Part-time proration factors applied: %.4f (CSRS component), %.4f (FERS component)\n", csrsFactor, fersFactor)
+	}
+
+	csrs := models.PensionComponent{System: "CSRS", YearsOfService: csrsYears, GrossAnnuity: csrsGross, COLARule: "Full CSRS COLA from the first year"}
+	fers := models.PensionComponent{System: "FERS", YearsOfService: fersService, GrossAnnuity: fersGross, COLARule: "FERS diet COLA, starting at age 62"}
+
+	// The age reduction applies to the whole annuity
+	csrs.AgeReduction = csrsGross * eligibility.ReductionPercent
+	fers.AgeReduction = fersGross * eligibility.ReductionPercent
+	earlyReduction := csrs.AgeReduction + fers.AgeReduction
+	if earlyReduction > 0 {
+		notes += fmt.Sprintf("Early retirement reduction applied: %.2f\n", earlyReduction)
+	}
+
+	// Each component carries the survivor cost and benefit of its own system
+	csrsBase := csrsGross - csrs.AgeReduction
+	fersBase := fersGross - fers.AgeReduction
+	var csrsNote, fersNote string
+	yearsYounger := beneficiaryYearsYounger(input.AgeAtRetirement, input.BeneficiaryAge)
+	csrs.SurvivorReduction, csrs.SurvivorAnnuity, csrsNote = getSurvivorReduction("CSRS", input.SurvivorBenefitElection, csrsBase, input.SurvivorBaseAmount, yearsYounger)
+	fers.SurvivorReduction, fers.SurvivorAnnuity, fersNote = getSurvivorReduction("FERS", input.SurvivorBenefitElection, fersBase, 0, yearsYounger)
+	if input.SurvivorBenefitElection == "max" || input.SurvivorBenefitElection == "partial" || input.SurvivorBenefitElection == "insurable interest" {
+		notes += csrsNote + " (CSRS component); " + fersNote + " (FERS component).\n"
+	}
+
+	csrs.AnnualAnnuity = csrsBase - csrs.SurvivorReduction
+	fers.AnnualAnnuity = fersBase - fers.SurvivorReduction
+
+	if input.ProjectionYears > 0 {
+		startAge := input.AgeAtRetirement
+		if input.AnnuityStartAge > startAge {
+			startAge = input.AnnuityStartAge
+		}
+		csrs.ProjectedAmounts = projectComponentCOLA(csrs.AnnualAnnuity, input.COLARate, "CSRS", startAge, input.ProjectionYears)
+		fers.ProjectedAmounts = projectComponentCOLA(fers.AnnualAnnuity, input.COLARate, "FERS", startAge, input.ProjectionYears)
+	}
+
+	total := csrs.AnnualAnnuity + fers.AnnualAnnuity
+	startAge := max(input.AgeAtRetirement, input.AnnuityStartAge)
+	taxFree, taxNotes := monthlyTaxFree(input.EmployeeContributions, total/12.0, startAge, input.BeneficiaryAge, input.SurvivorBenefitElection)
+	notes += taxNotes
+	return models.FERSTransfereeCalculationResult{
+		CSRSComponent:            csrs,
+		FERSComponent:            fers,
+		AnnualPension:            total,
+		MonthlyPension:           total / 12.0,
+		EarlyRetirementReduction: earlyReduction,
+		SurvivorBenefitReduction: csrs.SurvivorReduction + fers.SurvivorReduction,
+		SurvivorAnnuity:          csrs.SurvivorAnnuity + fers.SurvivorAnnuity,
+		ComputationServiceYears:  csrsService + fersService,
+		RetirementType:           eligibility.RetirementType,
+		SRSPayable:               eligibility.SRSPayable,
+		MonthlyTaxFreeAmount:     taxFree,
+		Notes:                    notes,
+	}
+}
@@ -0,0 +1,22 @@
+package models
+
+// AlternativeFormOfAnnuityInput holds data for the Alternative Form of Annuity (lump sum of contributions plus a reduced annuity).
+type AlternativeFormOfAnnuityInput struct {
+	PensionType                  string               // "FERS", "CSRS", "CSRSOffset"
+	FERSInput                    FERSCalculationInput // Used when PensionType is "FERS"; EmployeeContributions is the lump sum
+	CSRSInput                    CSRSCalculationInput // Used for CSRS; EmployeeContributions is the lump sum
+	HasLifeThreateningAffliction bool                 // AFA is only available to retirees with a life-threatening affliction
+}
+
+// AlternativeFormOfAnnuityResult holds the lump sum and the reduced annuity.
+type AlternativeFormOfAnnuityResult struct {
+	IsAvailable        bool    // True if the AFA may be elected
+	FullAnnuity        float64 // Annual annuity without the AFA
+	LumpSum            float64 // Employee contributions paid as a lump sum
+	PresentValueFactor float64 // Approximate present value factor (monthly annuity) at the retiree's age
+	AnnualReduction    float64 // Annual annuity reduction for the lump sum
+	ReducedAnnuity     float64 // Annual annuity after the AFA reduction
+	MonthlyAnnuity     float64 // Monthly annuity after the AFA reduction
+	IsEstimate         bool    // True: the reduction uses an approximate factor, not OPM's published one
+	Notes              string  // Any warnings, special conditions, or info
+}
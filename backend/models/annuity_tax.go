@@ -0,0 +1,32 @@
+package models
+
+// SimplifiedMethodInput holds data for the IRS Simplified Method tax-free annuity recovery.
+type SimplifiedMethodInput struct {
+	EmployeeContributions float64   // Cost in the contract: after-tax retirement contributions
+	MonthlyAnnuity        float64   // Gross monthly annuity at the annuity start date
+	AgeAtAnnuityStart     int       // Annuitant's age at the annuity start date
+	BeneficiaryAge        int       // Optional: survivor annuitant's age at the start date (selects the joint-life divisor)
+	StartYear             int       // Calendar year of the first payment
+	StartMonth            int       // Month of the first payment, 1-12 (default 1)
+	YearlyGrossAnnuity    []float64 // Optional: gross annuity paid in each calendar year from StartYear
+	Years                 int       // Years to schedule when YearlyGrossAnnuity is not given
+}
+
+// SimplifiedMethodYear is the tax-free and taxable annuity for one calendar year.
+type SimplifiedMethodYear struct {
+	Year            int     // Calendar year
+	GrossAnnuity    float64 // Annuity paid in the year
+	TaxFree         float64 // Recovery of contributions (not taxable)
+	Taxable         float64 // Taxable portion of the annuity
+	UnrecoveredCost float64 // Contributions still to be recovered at year end
+}
+
+// SimplifiedMethodResult holds the monthly exclusion and the recovery schedule.
+type SimplifiedMethodResult struct {
+	Divisor              int                    // Number of anticipated payments from the IRS table
+	JointLife            bool                   // True if the joint-life table was used
+	MonthlyTaxFree       float64                // Tax-free amount of each monthly payment
+	Schedule             []SimplifiedMethodYear // Year-by-year recovery
+	RecoveryCompleteYear int                    // Year the cost is fully recovered (0 if not within the schedule)
+	Notes                string                 // Any warnings, special conditions, or info
+}
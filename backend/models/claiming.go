@@ -0,0 +1,34 @@
+package models
+
+// ClaimingStrategyInput holds both spouses' records for searching the claiming-age grid.
+type ClaimingStrategyInput struct {
+	Worker               SocialSecurityCalculationInput // Worker's record; ClaimAge is the baseline strategy
+	Spouse               SocialSecurityCalculationInput // Spouse's record; ClaimAge is the baseline strategy
+	DiscountRate         float64                        // Annual real discount rate for present values (e.g. 0.02)
+	MortalityModel       string                         // "gompertz" (default) or "fixed"
+	WorkerLifeExpectancy int                            // Modal age at death ("gompertz") or age at death ("fixed"); default 85
+	SpouseLifeExpectancy int                            // Same for the spouse; default 85
+	EarlyDeathAge        int                            // Age at which one spouse dies in the early-death test; default 70
+	TopStrategies        int                            // Number of strategies to report; default 5
+}
+
+// ClaimingStrategy is one pair of claiming ages and its lifetime value. Ages are in months.
+type ClaimingStrategy struct {
+	WorkerClaimAgeMonths int     // Worker's claiming age
+	SpouseClaimAgeMonths int     // Spouse's claiming age
+	ExpectedValue        float64 // Discounted, mortality-weighted lifetime household benefits
+	GainOverBaseline     float64 // ExpectedValue minus the baseline's
+	BreakEvenAge         int     // Worker age by which cumulative benefits catch up with the baseline (0 if never behind or never caught up)
+	WorkerDiesEarlyValue float64 // Lifetime value if the worker dies at EarlyDeathAge
+	SpouseDiesEarlyValue float64 // Lifetime value if the spouse dies at EarlyDeathAge
+}
+
+// ClaimingStrategyResult holds the best strategies and the baseline they are ranked against.
+type ClaimingStrategyResult struct {
+	Strategies          []ClaimingStrategy              // Top strategies, best first
+	Baseline            ClaimingStrategy                // Both spouses claiming at their input ages
+	Single              SocialSecurityCalculationResult // Worker's single-person result
+	SingleValue         float64                         // Lifetime value of the worker's own benefit alone
+	StrategiesEvaluated int                             // Claiming-age pairs searched
+	Notes               string                          // Any warnings, method notes, etc.
+}
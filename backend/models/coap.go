@@ -0,0 +1,42 @@
+package models
+
+// CourtOrderAward describes a former spouse's court-ordered share of the annuity.
+type CourtOrderAward struct {
+	AwardType           string  // "fixed", "percentage", "marital fraction"; empty for none
+	FixedMonthlyAmount  float64 // "fixed": monthly amount awarded
+	Percentage          float64 // "percentage": share of the basis; "marital fraction": share of the marital portion (e.g. 0.5)
+	MaritalServiceYears float64 // "marital fraction": creditable service during the marriage
+	Basis               string  // "gross" (default) or "net" (after survivor reductions and health/life premiums)
+	FixedAmountGetsCOLA bool    // "fixed": true if the order grants COLAs on the fixed amount
+}
+
+// COAPCalculationInput holds data for a court order acceptable for processing and any former-spouse survivor annuity.
+type COAPCalculationInput struct {
+	PensionType              string          // "FERS", "CSRS", "CSRSOffset"
+	GrossAnnuity             float64         // Annual annuity before any survivor reduction
+	TotalServiceYears        float64         // Creditable service at retirement (marital fraction denominator)
+	Award                    CourtOrderAward // Former spouse's share of the annuity
+	FormerSpouseSurvivorBase float64         // Annual base for a court-ordered former-spouse survivor annuity (0 = none)
+	CurrentSpouseElection    string          // "max", "partial", "none"
+	CurrentSpouseBase        float64         // Partial election: base for the current spouse (default half the annuity)
+	HealthPremiums           float64         // Annual health premiums deducted from the annuity (net basis)
+	LifeInsurancePremiums    float64         // Annual life insurance premiums deducted from the annuity (net basis)
+	COLARate                 float64         // Annual COLA for the yearly projection
+	ProjectionYears          int             // Years to project (0 for none)
+}
+
+// COAPCalculationResult holds the split of the annuity between the retiree and the former spouse.
+type COAPCalculationResult struct {
+	GrossAnnuity                 float64   // Annual annuity before survivor reductions
+	SurvivorReduction            float64   // Combined cost of the current and former spouse survivor annuities
+	CurrentSpouseSurvivorCost    float64   // Part of SurvivorReduction restored if the current spouse dies first or the marriage ends
+	AnnuityAfterSurvivor         float64   // Annual annuity paid by OPM after survivor reductions
+	FormerSpouseAward            float64   // Annual amount paid to the former spouse under the order
+	RetireeAnnuity               float64   // Annual annuity left to the retiree
+	CurrentSpouseSurvivorAnnuity float64   // Survivor annuity payable to the current spouse
+	FormerSpouseSurvivorAnnuity  float64   // Survivor annuity payable to the former spouse
+	SurvivorCapApplied           bool      // True if the current spouse election was limited by the combined cap
+	YearlyFormerSpouseAward      []float64 // Award for each projected year
+	YearlyRetireeAnnuity         []float64 // Retiree's share for each projected year
+	Notes                        string    // Any warnings, special conditions, or info
+}
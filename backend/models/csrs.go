@@ -5,26 +5,45 @@ type CSRSCalculationInput struct {
 	High3Salary              float64 // Highest average basic pay over 3 consecutive years
 	YearsOfService           float64 // Total years (including partial years) of creditable service
 	UnusedSickLeaveMonths    int     // Unused sick leave in months (converted to service credit)
+	UnusedSickLeaveHours     float64 // Optional: unused sick leave in hours (converted with the OPM chart; overrides months)
+	SickLeaveSchedule        string  // Optional: "fulltime" (2087-hour chart), "parttime", "firefighter" (2756-hour chart)
+	SickLeaveHoursPerWeek    float64 // Part-time schedule only: scheduled hours per week
 	IsPartTime               bool    // True if any part-time service
 	PartTimeProrationFactor  float64 // Proration factor (1.0 = full time)
 	EmployeeContributions    float64 // For tax-free portion calculation (optional)
-	SurvivorBenefitElection  string  // e.g., "max", "partial", "none" (optional)
+	BeneficiaryAge           int     // Optional: survivor annuitant's age at retirement (joint-life divisor; insurable interest age difference)
+	SurvivorBenefitElection  string  // e.g., "max", "partial", "insurable interest", "none" (optional)
+	SurvivorBaseAmount       float64 // Partial election: annual base for the 55% survivor annuity (defaults to half the annuity)
 	IsCSRSOffset             bool    // True if CSRS Offset
 	YearsOfOffsetService     float64 // Only for CSRS Offset
 	SSAt62WithOffset         float64 // Only for CSRS Offset: SS benefit at 62 with Offset earnings
 	SSAt62WithoutOffset      float64 // Only for CSRS Offset: SS benefit at 62 without Offset earnings
 	AgeAtRetirement          int     // Age at retirement (for reductions)
+	AgeAtRetirementMonths    int     // Optional: additional months of age at retirement (0-11)
+	RetirementOption         string  // Optional: "Disability" or "SpecialProvision" (no age reduction); empty for voluntary
+	EligibleForSocialSecurity bool   // True if eligible for Social Security at 62 (military service credited without a deposit is then removed)
+	ServiceHistory           []ServicePeriod // Optional: dated service periods; overrides YearsOfService and proration when set
 }
 
 // CSRSCalculationResult holds the calculated pension and related details.
 type CSRSCalculationResult struct {
 	AnnualPension            float64 // Gross annual pension
+	AnnualPensionAt62        float64 // Annual pension from 62 once military service without a deposit is removed (equals AnnualPension otherwise)
+	MilitaryRemovedAt62Years float64 // Military service without a deposit removed at 62 (Social Security eligible only)
 	MonthlyPension           float64 // Gross monthly pension
 	EarlyRetirementReduction float64 // Total reduction for early retirement (if any)
 	SickLeaveServiceCredit   float64 // Years added from unused sick leave
+	SickLeaveCredit          ServiceDuration // Sick leave credit in years/months/days
+	TotalComputationService  ServiceDuration // Computation service plus sick leave, odd days dropped
 	ProrationApplied         bool    // True if part-time proration applied
 	ProratedPension          float64 // Pension after proration (if applicable)
 	SurvivorBenefitReduction float64 // Reduction for survivor benefit election
+	SurvivorAnnuity          float64 // Annual survivor annuity payable at the retiree's death
 	OffsetReduction          float64 // Reduction for CSRS Offset (if applicable)
+	EligibilityServiceYears  float64 // Creditable service used for eligibility
+	ComputationServiceYears  float64 // Creditable service used in the annuity computation (before sick leave)
+	DepositItems             []DepositItem // Deposits and redeposits from the service history and how each was credited
+	DepositReduction         float64 // Permanent reduction for unpaid deposits (10%) and pre-3/1/91 redeposits (actuarial)
+	MonthlyTaxFreeAmount     float64 // Tax-free recovery of EmployeeContributions per monthly payment (IRS Simplified Method)
 	Notes                    string  // Any warnings, special conditions, or info
 }
@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// FERSDeferredCalculationInput holds data for a deferred or postponed FERS annuity.
+type FERSDeferredCalculationInput struct {
+	FERSInput       FERSCalculationInput // High3Salary is the High-3 at separation (frozen, not inflation-adjusted)
+	SeparationDate  time.Time            // Date of separation from federal service
+	CommencementAge int                  // Chosen age for the annuity to begin (0 = earliest possible)
+}
+
+// DeferredAnnuityOption is the annuity payable for one commencement age.
+type DeferredAnnuityOption struct {
+	CommencementAge  int     // Age the annuity begins
+	ReductionPercent float64 // MRA+10 age reduction at this commencement age
+	AnnualAnnuity    float64 // Annual annuity at this commencement age
+	RetirementType   string  // Eligibility classification at this commencement age
+}
+
+// FERSDeferredCalculationResult holds the deferred annuity and the commencement-age table.
+type FERSDeferredCalculationResult struct {
+	AgeAtSeparation          int                     // Whole years of age at separation
+	EarliestCommencementAge  int                     // First age an annuity can begin
+	UnreducedCommencementAge int                     // First age the annuity is payable without age reduction
+	CommencementAge          int                     // Age used for AnnualAnnuity
+	AnnualAnnuity            float64                 // Annual annuity at the chosen commencement age
+	MonthlyAnnuity           float64                 // Monthly annuity at the chosen commencement age
+	ReductionPercent         float64                 // Age reduction at the chosen commencement age
+	RetirementType           string                  // "Deferred" or "MRA+10 Postponed"
+	SRSPayable               bool                    // False for a deferred or postponed annuity (the SRS is lost)
+	FEHBContinues            bool                    // True if FEHB continues or can be reinstated when the annuity begins
+	CommencementTable        []DeferredAnnuityOption // Annuity for each commencement age up to 62
+	Notes                    string                  // Any warnings, special conditions, or info
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// DepositItem itemizes the deposit or redeposit for one civilian service period and its effect on the annuity.
+type DepositItem struct {
+	StartDate        time.Time // First day of the period
+	EndDate          time.Time // Last day of the period
+	Kind             string    // "deposit" (non-deduction service) or "redeposit" (refunded service)
+	Paid             bool      // True if the deposit or redeposit was paid
+	AmountDue        float64   // Deposit or redeposit principal
+	Interest         float64   // Interest accrued to retirement
+	Treatment        string    // "credited", "10% reduction", "actuarial reduction", "eligibility only", "not creditable"
+	AnnuityReduction float64   // Annual annuity reduction for unpaid amounts (CSRS only)
+	IsEstimate       bool      // True if AnnuityReduction uses the approximate present value factor
+}
@@ -0,0 +1,36 @@
+package models
+
+// FERSDisabilityCalculationInput holds data for a FERS disability retirement projection.
+type FERSDisabilityCalculationInput struct {
+	High3Salary             float64 // High-3 at disability retirement
+	YearsOfService          float64 // Creditable service at disability retirement
+	AgeAtDisability         int     // Age at disability retirement
+	SSDisabilityBenefit     float64 // Annual Social Security disability benefit (0 if not entitled)
+	COLARate                float64 // Assumed annual CPI increase
+	YearsToProject          int     // Length of the year-by-year series (0 defaults to age 90)
+	SurvivorBenefitElection string  // "max", "partial", "insurable interest", "none"
+	BeneficiaryAge          int     // Insurable interest: beneficiary's age at retirement
+	EmployeeContributions   float64 // Optional: after-tax contributions recovered tax-free (IRS Simplified Method)
+}
+
+// DisabilityAnnuityYear is one year of a disability annuity projection.
+type DisabilityAnnuityYear struct {
+	Age      int     // Annuitant's age during the year
+	Phase    string  // "60%", "40%", "Earned", "Recomputed"
+	Annuity  float64 // Annual annuity paid after offsets and survivor reduction
+	SSOffset float64 // Social Security offset applied in the year
+	COLA     float64 // COLA rate applied at the start of the year
+}
+
+// FERSDisabilityCalculationResult holds the disability annuity series and the age-62 recomputation.
+type FERSDisabilityCalculationResult struct {
+	YearlyAnnuity            []DisabilityAnnuityYear // Year-by-year annuity from disability retirement
+	FirstYearAnnuity         float64                 // Annuity for the first 12 months
+	EarnedAnnuity            float64                 // 1% x High-3 x service, used when higher than the disability formula
+	RecomputedHigh3          float64                 // High-3 increased by the COLAs paid during disability
+	RecomputedServiceYears   float64                 // Service plus time on disability rolls to age 62
+	RecomputedAnnuityAt62    float64                 // Annuity after the age-62 recomputation
+	SurvivorBenefitReduction float64                 // Survivor election cost in the first year
+	MonthlyTaxFreeAmount     float64                 // Tax-free recovery of EmployeeContributions per monthly payment
+	Notes                    string                  // Any warnings, special conditions, or info
+}
@@ -0,0 +1,27 @@
+package models
+
+// FERSEligibilityInput holds the data needed to classify a FERS retirement.
+type FERSEligibilityInput struct {
+	BirthYear        int     // Year of birth (for MRA lookup)
+	BirthMonth       int     // Month of birth, 1-12 (for exact age at retirement)
+	AgeAtRetirement  int     // Whole years of age at separation (used when RetirementYear is not set)
+	RetirementYear   int     // Optional: year of separation, for a month-precise age
+	RetirementMonth  int     // Optional: month of separation, 1-12
+	YearsOfService   float64 // Creditable service for eligibility (excludes sick leave)
+	RetirementOption string  // Optional: "VERA", "DSR", "Disability", "Deferred"; empty for voluntary
+	AnnuityStartAge  int     // Optional: age the annuity commences (postponed MRA+10 or deferred)
+}
+
+// FERSEligibilityResult holds the retirement classification and what it implies.
+type FERSEligibilityResult struct {
+	MRAYears            int     // Minimum Retirement Age, years part
+	MRAMonths           int     // Minimum Retirement Age, months part
+	RetirementType      string  // "MRA+30", "60+20", "62+5", "MRA+10", "MRA+10 Postponed", "VERA", "DSR", "Deferred", "Disability", "Ineligible"
+	IsEligible          bool    // True if any annuity is payable
+	IsImmediate         bool    // True if the annuity starts at separation
+	ReductionPercent    float64 // Age reduction as a fraction of the annuity (e.g. 0.25)
+	AppliesHigherFactor bool    // True if the 1.1% multiplier applies
+	SRSPayable          bool    // True if the FERS Annuity Supplement is payable
+	SRSStartsAtMRA      bool    // True if the SRS is deferred until MRA (VERA/DSR before MRA)
+	Notes               string  // Explanation of the classification
+}
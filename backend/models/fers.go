@@ -6,11 +6,22 @@ type FERSCalculationInput struct {
 	YearsOfService            float64 // Total years (including partial years) of creditable service
 	AgeAtRetirement           int     // Age at retirement
 	UnusedSickLeaveMonths     int     // Unused sick leave in months (converted to service credit)
+	UnusedSickLeaveHours      float64 // Optional: unused sick leave in hours (converted with the OPM chart; overrides months)
+	SickLeaveSchedule         string  // Optional: "fulltime" (2087-hour chart), "parttime", "firefighter" (2756-hour chart)
+	SickLeaveHoursPerWeek     float64 // Part-time schedule only: scheduled hours per week
 	IsPartTime                bool    // True if any part-time service
 	PartTimeProrationFactor   float64 // Proration factor (1.0 = full time)
-	IsAge62With20Years        bool    // True if age 62+ with 20+ years (for 1.1% multiplier)
-	SurvivorBenefitElection   string  // e.g., "max", "partial", "none" (optional)
+	SurvivorBenefitElection   string  // e.g., "max", "partial", "insurable interest", "none" (optional)
 	EmployeeContributions     float64 // For tax-free portion calculation (optional)
+	BeneficiaryAge            int     // Optional: survivor annuitant's age at retirement (joint-life divisor; insurable interest age difference)
+	ServiceHistory            []ServicePeriod // Optional: dated service periods; overrides YearsOfService and proration when set
+	BirthYear                 int     // Year of birth (for MRA lookup)
+	BirthMonth                int     // Month of birth, 1-12
+	RetirementYear            int     // Optional: year of separation, for month-precise MRA checks
+	RetirementMonth           int     // Optional: month of separation, 1-12
+	RetirementOption          string  // Optional: "VERA", "DSR", "Disability", "Deferred"; empty for voluntary
+	AnnuityStartAge           int     // Optional: commencement age for a postponed or deferred annuity
+	SSDisabilityBenefit       float64 // Disability only: annual Social Security disability benefit
 }
 
 // FERSCalculationResult holds the calculated pension and related details.
@@ -19,8 +30,17 @@ type FERSCalculationResult struct {
 	MonthlyPension            float64 // Gross monthly pension
 	EarlyRetirementReduction  float64 // Total reduction for early retirement (if any)
 	SickLeaveServiceCredit    float64 // Years added from unused sick leave
+	SickLeaveCredit           ServiceDuration // Sick leave credit in years/months/days
+	TotalComputationService   ServiceDuration // Computation service plus sick leave, odd days dropped
 	ProrationApplied          bool    // True if part-time proration applied
 	ProratedPension           float64 // Pension after proration (if applicable)
 	SurvivorBenefitReduction  float64 // Reduction for survivor benefit election
+	SurvivorAnnuity           float64 // Annual survivor annuity payable at the retiree's death
+	EligibilityServiceYears   float64 // Creditable service used for eligibility
+	RetirementType            string  // Eligibility classification (e.g. "MRA+30", "MRA+10")
+	SRSPayable                bool    // True if the FERS Annuity Supplement is payable
+	ComputationServiceYears   float64 // Creditable service used in the annuity computation (before sick leave)
+	DepositItems              []DepositItem // Deposits and redeposits from the service history and how each was credited
+	MonthlyTaxFreeAmount      float64 // Tax-free recovery of EmployeeContributions per monthly payment (IRS Simplified Method)
 	Notes                     string  // Any warnings, special conditions, or info
 }
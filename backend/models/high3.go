@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// SalaryRate is a rate of basic pay in effect from a given date until the next change.
+type SalaryRate struct {
+	EffectiveDate   time.Time // First day the rate is in effect
+	Grade           string    // Optional: pay plan and grade, e.g. "GS-13"
+	Step            int       // Optional: step within the grade
+	BasePay         float64   // Annual base rate before locality
+	LocalityPercent float64   // Locality adjustment as a fraction (e.g. 0.3326)
+	AnnualRate      float64   // Optional: total annual basic pay; overrides BasePay and LocalityPercent
+	Reason          string    // Optional: "promotion", "step increase", "annual raise", "locality change", "projected raise"
+}
+
+// High3CalculationInput holds the dated pay history used to compute the High-3 average salary.
+type High3CalculationInput struct {
+	SalaryHistory         []SalaryRate // Basic pay rates, in any order
+	RetirementDate        time.Time    // Date of separation; the last day of pay is the day before
+	ProjectedRaisePercent float64      // Annual January raise assumed after the last entry (e.g. 0.02)
+}
+
+// High3CalculationResult holds the High-3 average and the window it came from.
+type High3CalculationResult struct {
+	High3Salary    float64      // Highest average basic pay over 36 consecutive months
+	WindowStart    time.Time    // First day of the High-3 window
+	WindowEnd      time.Time    // Last day of the High-3 window
+	WindowRates    []SalaryRate // Rates in effect during the window, including projected raises
+	ProjectedRates []SalaryRate // January raises projected after the last entry
+	Notes          string       // Any warnings, special conditions, or info
+}
@@ -0,0 +1,36 @@
+package models
+
+// HouseholdMember is one spouse's Social Security record for household benefits.
+type HouseholdMember struct {
+	BirthYear      int     // Year of birth (for FRA and deemed filing)
+	PIA            float64 // Monthly primary insurance amount (benefit at FRA)
+	ClaimAge       int     // Age retirement benefits are claimed (62-70); 0 claims at FRA
+	ClaimAgeMonths int     // Optional: additional months of claiming age (0-11)
+	DeathAge       int     // Optional: age during which the spouse dies; 0 if not modeled
+}
+
+// HouseholdSocialSecurityInput holds both spouses' records.
+type HouseholdSocialSecurityInput struct {
+	Worker    HouseholdMember // Primary earner (the retiree in projections)
+	Spouse    HouseholdMember // Spouse
+	TrustFund TrustFundPolicy // Optional: benefit cut after the OASI trust fund is depleted
+}
+
+// HouseholdMemberResult holds one spouse's household benefits. Ages are in months.
+type HouseholdMemberResult struct {
+	ClaimAgeMonths         int     // Age own retirement benefits start
+	OwnBenefit             float64 // Monthly retirement benefit on own record
+	SpousalBenefit         float64 // Monthly spousal benefit paid on top of the own benefit
+	SpousalStartAgeMonths  int     // Age the spousal benefit starts (0 if none)
+	RestrictedBenefit      float64 // Monthly spousal-only benefit under a restricted application (pre-1954 births)
+	SurvivorBenefit        float64 // Total monthly benefit after the other spouse dies
+	SurvivorStartAgeMonths int     // Age the survivor benefit starts
+}
+
+// HouseholdSocialSecurityResult holds both spouses' benefits.
+type HouseholdSocialSecurityResult struct {
+	Worker          HouseholdMemberResult // Primary earner's benefits
+	Spouse          HouseholdMemberResult // Spouse's benefits
+	CombinedMonthly float64               // Household monthly benefit once both have claimed
+	Notes           string                // Any warnings, method notes, etc.
+}
@@ -0,0 +1,50 @@
+package models
+
+import "time"
+
+// MilitaryPayPeriod is one period of active military service and the basic pay earned in it.
+type MilitaryPayPeriod struct {
+	StartDate time.Time // First day of active duty
+	EndDate   time.Time // Last day of active duty (inclusive)
+	BasicPay  float64   // Total military basic pay earned during the period
+}
+
+// MilitaryDepositCalculationInput holds data for a post-1956 military service deposit.
+type MilitaryDepositCalculationInput struct {
+	RetirementSystem    string              // "FERS", "CSRS", "CSRSOffset"
+	Periods             []MilitaryPayPeriod // Periods of military service
+	CivilianHireDate    time.Time           // Date first employed in a covered civilian position (starts the grace period)
+	PayoffDate          time.Time           // Date the deposit will be paid in full
+	AssumedInterestRate float64             // OPM variable rate assumed for years not in the embedded schedule (e.g. 0.04)
+	DepositPaid         bool                // True if the deposit has been paid in full
+	High3Salary         float64             // For the break-even view
+	AnnuityMultiplier   float64             // Optional: accrual rate for the purchased years (default 1% FERS, 2% CSRS)
+	AgeAtRetirement     int                 // For the break-even view
+	LifeExpectancy      int                 // Age the lifetime annuity increase runs to (default 85)
+}
+
+// MilitaryDepositPeriod is the deposit due for one period (or the part of it in one rate era).
+type MilitaryDepositPeriod struct {
+	StartDate   time.Time // First day of the period or era slice
+	EndDate     time.Time // Last day of the period or era slice
+	BasicPay    float64   // Basic pay attributed to the slice
+	DepositRate float64   // Deposit rate for the era (e.g. 0.03)
+	Deposit     float64   // Deposit before interest
+}
+
+// MilitaryDepositCalculationResult holds the deposit due and the break-even analysis.
+type MilitaryDepositCalculationResult struct {
+	MilitaryService         ServiceDuration         // Length of military service
+	MilitaryYears           float64                 // MilitaryService as fractional years
+	CreditedYears           float64                 // Years added to creditable service (0 until the deposit is paid)
+	PeriodDeposits          []MilitaryDepositPeriod // Deposit by period and rate era
+	InitialDeposit          float64                 // Deposit before interest
+	InterestFreeUntil       time.Time               // End of the interest-free grace period
+	InterestAccrued         float64                 // Interest through the payoff date
+	BalanceDue              float64                 // Deposit plus interest at the payoff date
+	AnnualAnnuityIncrease   float64                 // Extra annual annuity from the purchased years
+	BreakEvenYears          float64                 // Years of retirement for the extra annuity to repay the deposit
+	BreakEvenAge            float64                 // Age at which the deposit is repaid
+	LifetimeAnnuityIncrease float64                 // Extra annuity from retirement to LifeExpectancy
+	Notes                   string                  // Any warnings, special conditions, or info
+}
@@ -13,6 +13,12 @@ type MonteCarloInput struct {
 	InflationMean       float64 // Mean annual inflation (e.g., 0.025 for 2.5%)
 	InflationStdDev     float64 // Std dev of inflation
 	Seed                int64   // Optional: for deterministic tests
+	StartYear           int     // Calendar year of the first simulated year
+	BirthYear           int     // Retiree birth year (selects the trust fund cohort share)
+
+	SocialSecurityIncome    float64         // Scheduled annual Social Security assumed in AnnualWithdrawal
+	SocialSecurityStartYear int             // Calendar year Social Security starts (0 = from the first year)
+	TrustFund               TrustFundPolicy // Optional: shortfall withdrawn from the portfolio after depletion
 }
 
 // MonteCarloResult provides summary statistics and simulation output
@@ -21,4 +27,5 @@ type MonteCarloResult struct {
 	Percentiles             map[int]float64      // e.g., 10, 25, 50, 75, 90 percentiles of ending balance
 	YearlyBalances          [][]float64          // [simulation][year] balances
 	DepletionProbabilities  []float64            // Probability of depletion by year
+	BaselineSuccessRate     float64              // Success rate with scheduled Social Security benefits
 }
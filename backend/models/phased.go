@@ -0,0 +1,43 @@
+package models
+
+// PhasedRetirementInput holds data for an OPM phased retirement and the later composite retirement.
+type PhasedRetirementInput struct {
+	PensionType             string          // "FERS", "CSRS"
+	High3Salary             float64         // High-3 at entry into phased retirement
+	YearsOfService          float64         // Creditable service at entry into phased retirement
+	AgeAtPhasedRetirement   int             // Age at entry into phased retirement
+	BirthYear               int             // Year of birth (FERS MRA lookup)
+	FullTimeSalary          float64         // Full-time annual rate of basic pay at entry into phased retirement
+	PhasedYears             float64         // Years of half-time phased employment before full retirement
+	FinalHigh3Salary        float64         // High-3 at full retirement on full-time rates (0 = High3Salary)
+	UnusedSickLeaveMonths   int             // Credited only at full retirement
+	UnusedSickLeaveHours    float64         // Optional: unused sick leave in hours (converted with the OPM chart; overrides months)
+	SickLeaveSchedule       string          // Optional: "fulltime" (2087-hour chart), "parttime", "firefighter" (2756-hour chart)
+	SickLeaveHoursPerWeek   float64         // Part-time schedule only: scheduled hours per week
+	IsPartTime              bool            // True if any part-time service before phased retirement
+	PartTimeProrationFactor float64         // Proration factor for service before phased retirement (1.0 = full time)
+	ServiceHistory          []ServicePeriod // Optional: dated service periods; overrides YearsOfService and proration when set
+	COLARate                float64         // Assumed annual CPI increase applied to the phased annuity
+	SurvivorBenefitElection string          // Applies to the composite annuity: "max", "partial", "insurable interest", "none"
+	SurvivorBaseAmount      float64         // CSRS partial election: annual survivor base
+	BeneficiaryAge          int             // Insurable interest: beneficiary's age at full retirement
+}
+
+// PhasedRetirementResult holds the phased annuity, the half-time schedule and the composite annuity.
+type PhasedRetirementResult struct {
+	IsEligible            bool    // True if the employee may enter phased retirement
+	ImmediateAnnuity      float64 // Annuity the employee would receive on full retirement at entry
+	PhasedAnnuity         float64 // 50% of ImmediateAnnuity, paid during phased employment
+	PhasedSalary          float64 // Half-time salary
+	PhasedIncome          float64 // PhasedSalary plus PhasedAnnuity in the first year
+	WorkHoursPerYear      float64 // Half-time working hours
+	MentoringHours        float64 // At least 20% of working hours spent mentoring
+	AgeAtFullRetirement   int     // Age when phased employment ends
+	PhasedAnnuityWithCOLA float64 // Phased annuity with COLAs received through full retirement
+	SecondAnnuity         float64 // Annuity on the remaining share of prior service plus phased service
+	CompositeAnnuity      float64 // Phased annuity plus second annuity, before survivor reduction
+	SurvivorReduction     float64 // Survivor election cost on the composite annuity
+	SurvivorAnnuity       float64 // Survivor annuity payable at the retiree's death
+	AnnualAnnuity         float64 // Composite annuity after the survivor reduction
+	Notes                 string  // Any warnings, special conditions, or info
+}
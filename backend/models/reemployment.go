@@ -0,0 +1,33 @@
+package models
+
+// ReemployedAnnuitantInput holds data for a retiree reemployed by a federal agency.
+type ReemployedAnnuitantInput struct {
+	PensionType      string  // "FERS", "CSRS"
+	AnnualSalary     float64 // Annual salary at the start of reemployment
+	SalaryGrowthRate float64 // Annual raise applied to the salary
+	AnnualAnnuity    float64 // Annual annuity in the first year of reemployment
+	COLARate         float64 // Assumed annual CPI increase applied to the annuity
+	StartAge         int     // Age when reemployment starts
+	EndAge           int     // Last age of reemployment (inclusive)
+	OffsetWaived     bool    // True if the agency waived the salary offset
+}
+
+// ReemploymentYear is one year of reemployment.
+type ReemploymentYear struct {
+	Age                  int     // Reemployed annuitant's age during the year
+	Salary               float64 // Salary before the offset
+	AnnuityOffset        float64 // Salary reduction equal to the annuity (0 with a waiver)
+	SalaryPaid           float64 // Salary after the offset; earned income for the SRS and SS earnings tests
+	Annuity              float64 // Annuity paid during the year (unaffected by the offset)
+	SupplementalEligible bool    // True once a full year of reemployment has been completed by the end of the year
+}
+
+// ReemployedAnnuitantResult holds the salary offset and any supplemental annuity.
+type ReemployedAnnuitantResult struct {
+	Years                []ReemploymentYear // Year-by-year reemployment income
+	TotalEarnedIncome    float64            // Salary paid over the reemployment
+	SupplementalEligible bool               // True if a supplemental annuity is payable on separation
+	SupplementalAnnuity  float64            // Annual supplemental annuity from the end of reemployment
+	SupplementalStartAge int                // Age when the supplemental annuity starts
+	Notes                string             // Any warnings, special conditions, or info
+}
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// RefundComparisonInput holds data for comparing a refund of contributions with a deferred FERS annuity.
+type RefundComparisonInput struct {
+	FERSInput       FERSCalculationInput // High-3, service and EmployeeContributions at separation
+	SeparationDate  time.Time            // Date of separation
+	CommencementAge int                  // Deferred annuity commencement age (0 = earliest possible)
+	RefundInterest  float64              // Interest paid with the refund (the taxable part)
+	TaxRate         float64              // Marginal tax rate on the taxable part of the refund
+	DiscountRate    float64              // Annual discount rate for present values (e.g. 0.04)
+	COLARate        float64              // Assumed CPI for the FERS COLA once the annuitant is 62
+	MortalityModel  string               // "fixed" (paid until LifeExpectancy) or "gompertz" (survival curve)
+	LifeExpectancy  int                  // Age at death ("fixed") or modal age at death ("gompertz"); default 85
+}
+
+// RefundComparisonResult holds the after-tax refund and the present value of the deferred annuity.
+type RefundComparisonResult struct {
+	RefundGross          float64 // Contributions plus interest
+	RefundTax            float64 // Income tax and early withdrawal penalty on the interest
+	RefundNet            float64 // Refund after tax
+	DeferredAnnuity      float64 // Annual deferred annuity at commencement
+	CommencementAge      int     // Age the deferred annuity begins
+	DeferredPresentValue float64 // Present value at separation of the deferred annuity
+	Advantage            float64 // DeferredPresentValue minus RefundNet
+	Recommendation       string  // "deferred annuity" or "refund"
+	Notes                string  // Any warnings, special conditions, or info
+}
@@ -12,9 +12,14 @@ type RetirementCalculationInput struct {
 	COLAInput          COLACalculationInput
 	SurvivorInput      SurvivorBenefitCalculationInput
 	HealthInput        HealthPremiumCalculationInput
+	ReemploymentInput  ReemployedAnnuitantInput // Optional: reemployment after retirement (AnnualSalary 0 for none)
 
 	// Monte Carlo simulation (optional)
 	MonteCarloInput    MonteCarloInput
+
+	// Social Security trust fund shortfall (optional); fills the policy of any SS stream that has none
+	TrustFund          TrustFundPolicy
+	LifeExpectancy     int // Age through which lifetime Social Security is summed (0 uses RetireeAgeAtDeath, then 85)
 }
 
 // RetirementCalculationResult aggregates all outputs for the full retirement projection.
@@ -29,6 +34,8 @@ type RetirementCalculationResult struct {
 	COLAResult          COLACalculationResult
 	SurvivorResult      SurvivorBenefitCalculationResult
 	HealthResult        HealthPremiumCalculationResult
+	DisabilityResult    FERSDisabilityCalculationResult // Set when FERSInput.RetirementOption is "Disability"
+	ReemploymentResult  ReemployedAnnuitantResult       // Set when ReemploymentInput has a salary
 
 	// Monte Carlo simulation (optional)
 	MonteCarloResult    MonteCarloResult
@@ -38,4 +45,9 @@ type RetirementCalculationResult struct {
 	EffectiveTaxRate    float64 // Overall effective tax rate
 	TotalRetirementIncome float64 // Sum of all projected retirement income streams
 	Notes               string  // Any warnings or summary notes
+
+	// Trust fund shortfall impact against the scheduled-benefit baseline
+	ScheduledRetirementIncome float64 // TotalRetirementIncome with scheduled Social Security benefits
+	LifetimeScheduledSS       float64 // Social Security from claiming through LifeExpectancy, scheduled benefits
+	LifetimePayableSS         float64 // Same, after the trust fund shortfall
 }
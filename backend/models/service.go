@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// ServicePeriod describes one dated block of civilian or military service.
+type ServicePeriod struct {
+	StartDate             time.Time // First day of the period
+	EndDate               time.Time // Last day of the period (inclusive)
+	ServiceType           string    // "fulltime", "parttime", "temporary", "military"
+	ScheduledHoursPerWeek float64   // Part-time tour of duty in hours per week (40 = full time)
+	Refunded              bool      // True if retirement deductions for the period were refunded
+	DepositPaid           bool      // True if the deposit (temporary/military) or redeposit (refunded) was paid
+	BasicPay              float64   // Optional: basic pay earned in the period, for the deposit due on non-deduction service
+	RefundAmount          float64   // Optional: contributions refunded, for the redeposit due on refunded service
+	RefundDate            time.Time // Optional: date of the refund (redeposit interest runs from here)
+}
+
+// ServiceDuration is a length of service in OPM years/months/days form (30-day months).
+type ServiceDuration struct {
+	Years  int
+	Months int
+	Days   int
+}
+
+// ServiceHistoryInput holds the service periods used to derive creditable service.
+type ServiceHistoryInput struct {
+	RetirementSystem string          // "FERS", "CSRS", "CSRSOffset"
+	Periods          []ServicePeriod // Dated service periods, in any order
+}
+
+// ServiceCreditResult holds creditable service for eligibility and computation.
+type ServiceCreditResult struct {
+	EligibilityService         ServiceDuration // Service that counts toward retirement eligibility
+	ComputationService         ServiceDuration // Service that counts in the annuity computation
+	ComputationServiceWithDays ServiceDuration // ComputationService before odd days are dropped (sick leave is added to this)
+	EligibilityYears           float64         // EligibilityService as fractional years
+	ComputationYears           float64         // ComputationService as fractional years (odd days dropped)
+	ProrationFactor            float64         // OPM hours-based part-time proration factor (1.0 = full time)
+	IsPartTime                 bool            // True if any creditable part-time service after 4/7/1986
+	MilitaryRemovedAt62        ServiceDuration // CSRS military service without deposit, removed at 62 if eligible for Social Security
+	Notes                      string          // Periods excluded or partially credited, and why
+}
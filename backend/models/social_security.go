@@ -2,23 +2,86 @@ package models
 
 // SocialSecurityCalculationInput holds user data for SS benefit projection.
 type SocialSecurityCalculationInput struct {
-	BirthYear               int       // User's year of birth (for FRA)
-	CurrentAge              int       // User's age (for projections)
-	EarningsHistory         []float64 // Optional: indexed earnings for each year (up to 35 years)
-	EstimatedAnnualSalary   float64   // Optional: if no full history, use average salary
-	YearsWorked             int       // Optional: for simple estimate
-	UserProvidedEstimate62  float64   // Optional: SSA statement estimate at age 62
-	UserProvidedEstimateFRA float64   // Optional: SSA statement estimate at FRA
-	UserProvidedEstimate70  float64   // Optional: SSA statement estimate at age 70
-	ClaimAge                int       // Desired claiming age (62-70)
+	BirthYear               int             // User's year of birth (for FRA)
+	CurrentAge              int             // User's age (for projections)
+	EarningsHistory         []float64       // Optional: covered earnings for each year, oldest first
+	EarningsStartYear       int             // Optional: calendar year of EarningsHistory[0]; 0 means the history ends last year
+	FutureAnnualEarnings    float64         // Optional: projected earnings from the year after the history
+	StopWorkingAge          int             // Age at which projected earnings stop
+	WageGrowthRate          float64         // Optional: growth of projected earnings and AWI (0 uses 3%)
+	COLARate                float64         // Optional: assumed COLA for years without a published one
+	EstimatedAnnualSalary   float64         // Optional: if no full history, use average salary
+	YearsWorked             int             // Optional: for simple estimate
+	UserProvidedEstimate62  float64         // Optional: SSA statement estimate at age 62
+	UserProvidedEstimateFRA float64         // Optional: SSA statement estimate at FRA
+	UserProvidedEstimate70  float64         // Optional: SSA statement estimate at age 70
+	ClaimAge                int             // Desired claiming age (62-70); 0 claims at FRA
+	ClaimAgeMonths          int             // Optional: additional months of claiming age (0-11)
+	BirthMonth              int             // Optional: month of birth, 1-12, for the earnings test (0 = January)
+	YearlyEarnedIncome      []float64       // Optional: wages by calendar year from the claiming year, for the earnings test
+	EarningsLimitGrowthRate float64         // Annual growth of the earnings test limits after the latest published year
+	TrustFund               TrustFundPolicy // Optional: benefit cut after the OASI trust fund is depleted
 }
 
 // SocialSecurityCalculationResult holds the projected SS benefits.
 type SocialSecurityCalculationResult struct {
-	EstimatedAt62  float64 // Monthly benefit at age 62
-	EstimatedAtFRA float64 // Monthly benefit at FRA
-	EstimatedAt70  float64 // Monthly benefit at age 70
-	ClaimingAge    int     // Age used for benefit calculation
-	ClaimingAmount float64 // Monthly benefit at chosen claiming age
-	Notes          string  // Any warnings, method notes, etc.
+	EstimatedAt62     float64              // Monthly benefit at age 62
+	EstimatedAtFRA    float64              // Monthly benefit at FRA
+	EstimatedAt70     float64              // Monthly benefit at age 70
+	FRAYears          int                  // Full retirement age, years
+	FRAMonths         int                  // Full retirement age, additional months
+	ClaimingAge       int                  // Age used for benefit calculation
+	ClaimingAgeMonths int                  // Additional months of claiming age
+	ClaimingFactor    float64              // Benefit as a share of the PIA at the claiming age
+	ClaimingAmount    float64              // Monthly benefit at chosen claiming age
+	AIME              float64              // Average indexed monthly earnings
+	PIA               float64              // Primary insurance amount at 62, before COLAs
+	BendPoints        []float64            // PIA bend points for the eligibility year
+	EligibilityYear   int                  // Year the worker turns 62
+	EarningsTest      SSEarningsTestResult // Benefits withheld for earnings before FRA and the recomputed benefit
+	PayableAmount     float64              // Monthly claiming amount once a trust fund shortfall applies (ClaimingAmount if none)
+	PayableFromYear   int                  // First year the PayableAmount applies (0 if benefits are paid in full)
+	Notes             string               // Any warnings, method notes, etc.
+}
+
+// SSEarningsTestInput holds data for the retirement earnings test on benefits claimed before FRA.
+type SSEarningsTestInput struct {
+	BirthYear               int       // Year of birth (for FRA)
+	BirthMonth              int       // Month of birth, 1-12 (0 = January)
+	ClaimAge                int       // Claiming age (62-70); 0 claims at FRA
+	ClaimAgeMonths          int       // Additional months of claiming age (0-11)
+	MonthlyBenefit          float64   // Monthly benefit at the claiming age
+	COLARate                float64   // Annual COLA on the benefit in each year after the claiming year
+	YearlyEarnedIncome      []float64 // Wages by calendar year from the claiming year
+	EarningsLimitGrowthRate float64   // Annual growth of the limits after the latest published year
+}
+
+// SSEarningsTestYear is one calendar year of benefits before FRA.
+type SSEarningsTestYear struct {
+	Year           int     // Calendar year
+	Months         int     // Months of benefits before FRA in the year
+	Benefit        float64 // Benefits payable before the test, with COLAs since the claiming year
+	EarnedIncome   float64 // Earnings counted (before the FRA month in the FRA year)
+	EarningsLimit  float64 // Limit for the year ($1 for $2 over it; $1 for $3 in the FRA year)
+	Withheld       float64 // Benefits withheld
+	MonthsWithheld int     // Whole months of benefits withheld
+}
+
+// SSEarningsTestResult holds withheld benefits and the FRA recomputation of the reduction factor.
+type SSEarningsTestResult struct {
+	Years                  []SSEarningsTestYear // Years with benefits before FRA
+	TotalWithheld          float64              // Benefits withheld before FRA
+	MonthsWithheld         int                  // Months withheld, credited back at FRA
+	ClaimingFactor         float64              // Benefit as a share of the PIA at the claiming age
+	RecomputedFactor       float64              // Factor at FRA, not counting withheld months as early
+	MonthlyBenefitAfterFRA float64              // Monthly benefit from FRA after the recomputation (before COLAs)
+	Notes                  string               // Any warnings, method notes, etc.
+}
+
+// TrustFundPolicy cuts Social Security benefits to what is payable once the OASI trust fund is depleted.
+type TrustFundPolicy struct {
+	DepletionYear int             // First year of reduced benefits (0 = scheduled benefits paid in full)
+	PayableShare  float64         // Share of scheduled benefits payable from DepletionYear (0 uses 77%)
+	Mode          string          // "uniform" (default) or "cohort"
+	CohortShares  map[int]float64 // Cohort mode: payable share by birth year; other cohorts use PayableShare
 }
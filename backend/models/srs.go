@@ -9,6 +9,25 @@ type SRSCalculationInput struct {
 	IsImmediateUnreducedAnnuity bool    // True if eligible for immediate, unreduced annuity
 	ProjectedEarnedIncome       float64 // Earned income before age 62 (for earnings test)
 	RetirementYear              int     // Year of retirement (for earnings test threshold)
+	RetirementType              string  // Optional: FERS eligibility classification; overrides IsImmediateUnreducedAnnuity when set
+	BirthYear                   int     // Optional: year of birth; with RetirementYear, produces the yearly schedule
+	BirthMonth                  int     // Month of birth, 1-12
+	RetirementMonth             int     // Month of retirement, 1-12; the SRS starts the following month (0 = start of RetirementYear)
+	YearlyEarnedIncome          []float64 // Optional: earned income by calendar year from RetirementYear (overrides ProjectedEarnedIncome)
+	EarningsLimitGrowthRate     float64 // Annual growth of the earnings test limit after the latest published year
+	IsSpecialCategory           bool    // Law enforcement, firefighter, air traffic controller: no earnings test before MRA
+}
+
+// SRSYear is one calendar year of SRS payments.
+type SRSYear struct {
+	Year                  int     // Calendar year
+	Age                   int     // Retiree's age at the end of the year
+	Months                int     // Months of SRS paid in the year
+	Amount                float64 // SRS before the earnings test
+	EarnedIncome          float64 // Earnings subject to the test
+	EarningsLimit         float64 // Earnings test limit for the year
+	EarningsTestReduction float64 // $1 for every $2 of earnings over the limit
+	Paid                  float64 // SRS paid after the earnings test
 }
 
 // SRSCalculationResult holds the calculated SRS benefit and related details.
@@ -17,5 +36,6 @@ type SRSCalculationResult struct {
 	MonthlySRSAmount          float64 // Monthly SRS benefit after reductions
 	EarningsTestReduction     float64 // Reduction due to earnings test
 	IsEligible                bool    // True if eligible for SRS
+	YearlySRS                 []SRSYear // SRS by calendar year through the month of turning 62 (no COLA)
 	Notes                     string  // Any warnings, special conditions, or info
 }
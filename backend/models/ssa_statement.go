@@ -0,0 +1,18 @@
+package models
+
+// SSAEarningsYear is one year of the SSA earnings record.
+type SSAEarningsYear struct {
+	Year             int     // Calendar year of the earnings
+	FICAEarnings     float64 // Earnings taxed for Social Security
+	MedicareEarnings float64 // Earnings taxed for Medicare
+	Posted           bool    // False if SSA has not yet recorded the year's earnings
+}
+
+// SSAStatementImportResult holds the earnings record and estimates read from a "my Social Security" XML file.
+type SSAStatementImportResult struct {
+	Input         SocialSecurityCalculationInput // Birth year, earnings history, and statement estimates
+	Earnings      []SSAEarningsYear              // Earnings by year as listed in the file, oldest first
+	GapYears      []int                          // Years between the first and last posted year with no earnings
+	UnpostedYears []int                          // Years listed without recorded earnings
+	Notes         string                         // Any warnings, method notes, etc.
+}
@@ -2,22 +2,27 @@ package models
 
 // SurvivorBenefitCalculationInput holds data for projecting survivor annuity/income.
 type SurvivorBenefitCalculationInput struct {
-	PensionType         string  // "FERS", "CSRS", "CSRSOffset"
-	InitialAnnuity      float64 // Retiree's annual annuity before survivor reduction
-	SurvivorElection    string  // "max", "partial", "none"
-	SpouseAge           int     // Age of spouse/beneficiary
-	RetireeAgeAtDeath   int     // Age of retiree at death
-	COLARate            float64 // Annual COLA applied to survivor annuity
-	YearsToProject      int     // Years to project survivor income
-	IncludeSSSurvivor   bool    // Whether to include SS survivor benefit
-	SSSurvivorAmount    float64 // Annual SS survivor benefit (if included)
-	IncludeTSP          bool    // Whether to include TSP continuation
-	TSPBalanceAtDeath   float64 // TSP balance at death (if included)
-	OtherSurvivorIncome float64 // Other survivor income (optional)
+	PensionType             string                        // "FERS", "CSRS", "CSRSOffset"
+	InitialAnnuity          float64                       // Retiree's annual annuity before survivor reduction
+	SurvivorElection        string                        // "max", "partial", "insurable interest", "none"
+	SurvivorBaseAmount      float64                       // CSRS partial election: annual base the 55% survivor annuity is computed on
+	BeneficiaryYearsYounger int                           // Insurable interest: years the beneficiary is younger than the retiree
+	SpouseAge               int                           // Age of spouse/beneficiary
+	RetireeAgeAtDeath       int                           // Age of retiree at death
+	COLARate                float64                       // Annual COLA applied to survivor annuity
+	YearsToProject          int                           // Years to project survivor income
+	IncludeSSSurvivor       bool                          // Whether to include SS survivor benefit
+	SSSurvivorAmount        float64                       // Annual SS survivor benefit (if included)
+	SocialSecurity          *HouseholdSocialSecurityInput // Optional: computes SSSurvivorAmount when it is 0 (retiree is the worker)
+	IncludeTSP              bool                          // Whether to include TSP continuation
+	TSPBalanceAtDeath       float64                       // TSP balance at death (if included)
+	OtherSurvivorIncome     float64                       // Other survivor income (optional)
 }
 
 // SurvivorBenefitCalculationResult holds projected survivor income details.
 type SurvivorBenefitCalculationResult struct {
+	ReducedAnnuity         float64   // Retiree's annual annuity after the survivor reduction
+	SurvivorReduction      float64   // Annual cost of the election
 	InitialSurvivorAnnuity float64   // Survivor annuity in year 1
 	ProjectedAnnuities     []float64 // Survivor annuity for each projected year
 	TotalSurvivorIncome    float64   // Cumulative survivor income over projection
@@ -7,6 +7,8 @@ type TaxCalculationInput struct {
 	Age                  int     // Age of taxpayer
 	GrossPension         float64 // Total FERS/CSRS pension
 	TaxablePension       float64 // Taxable portion of pension (after exclusions)
+	AnnuityTaxFree       float64 // Tax-free recovery of contributions in GrossPension; used when TaxablePension is 0
+	FormerSpouseAward    float64 // Court-ordered share of GrossPension paid to a former spouse (taxed to the former spouse)
 	TSPWithdrawal        float64 // Taxable TSP withdrawals (Traditional)
 	TSPRothWithdrawal    float64 // Roth TSP withdrawals (not federally taxable)
 	SocialSecurity       float64 // Social Security benefit (taxable portion computed in logic)
@@ -0,0 +1,56 @@
+package models
+
+import "time"
+
+// FERSTransfereeCalculationInput holds data for the two-part annuity of a CSRS-to-FERS transferee.
+type FERSTransfereeCalculationInput struct {
+	High3Salary             float64         // High-3 used for both components
+	CSRSYearsOfService      float64         // Creditable service before the transfer (CSRS component)
+	FERSYearsOfService      float64         // Creditable service after the transfer (FERS component)
+	UnusedSickLeaveMonths   int             // Unused sick leave in months (credited to the CSRS component)
+	UnusedSickLeaveHours    float64         // Optional: unused sick leave in hours (converted with the OPM chart; overrides months)
+	SickLeaveSchedule       string          // Optional: "fulltime" (2087-hour chart), "parttime", "firefighter" (2756-hour chart)
+	SickLeaveHoursPerWeek   float64         // Part-time schedule only: scheduled hours per week
+	ServiceHistory          []ServicePeriod // Optional: dated service periods; overrides the component years when set
+	TransferDate            time.Time       // With ServiceHistory: effective date of the FERS election (later service is FERS)
+	AgeAtRetirement         int             // Age at retirement
+	BirthYear               int             // Year of birth (for MRA lookup)
+	BirthMonth              int             // Month of birth, 1-12
+	RetirementOption        string          // Optional: "VERA", "DSR", "Disability", "Deferred"; empty for voluntary
+	AnnuityStartAge         int             // Optional: commencement age for a postponed or deferred annuity
+	SurvivorBenefitElection string          // "max", "partial", "insurable interest", "none"
+	SurvivorBaseAmount      float64         // Partial election: CSRS-component base for the 55% survivor annuity
+	BeneficiaryAge          int             // Insurable interest: beneficiary's age at retirement
+	COLARate                float64         // Assumed annual CPI increase for the component COLA projection
+	ProjectionYears         int             // Years of component COLA to project (0 for none)
+	EmployeeContributions   float64         // Optional: after-tax contributions recovered tax-free (IRS Simplified Method)
+}
+
+// PensionComponent is one part of a multi-part annuity.
+type PensionComponent struct {
+	System            string    // "CSRS" or "FERS"
+	YearsOfService    float64   // Service credited to this component (including any sick leave)
+	GrossAnnuity      float64   // Component annuity before reductions
+	AgeReduction      float64   // Share of the age reduction charged to this component
+	SurvivorReduction float64   // Survivor election cost charged to this component
+	AnnualAnnuity     float64   // Component annuity after reductions
+	SurvivorAnnuity   float64   // Survivor annuity attributable to this component
+	COLARule          string    // How the component is adjusted for COLA
+	ProjectedAmounts  []float64 // Component annuity for each projected year after retirement
+}
+
+// FERSTransfereeCalculationResult holds the combined annuity and its components.
+type FERSTransfereeCalculationResult struct {
+	CSRSComponent            PensionComponent
+	FERSComponent            PensionComponent
+	AnnualPension            float64 // Combined annuity after reductions
+	MonthlyPension           float64 // Combined monthly annuity
+	EarlyRetirementReduction float64 // Total age reduction
+	SurvivorBenefitReduction float64 // Total survivor election cost
+	SurvivorAnnuity          float64 // Combined survivor annuity
+	ComputationServiceYears  float64 // CSRS plus FERS creditable service in the computation (before sick leave)
+	RetirementType           string  // FERS eligibility classification
+	SRSPayable               bool    // True if the FERS Annuity Supplement is payable
+	MonthlyTaxFreeAmount     float64 // Tax-free recovery of EmployeeContributions per monthly payment
+	Notes                    string  // Any warnings, special conditions, or info
+}
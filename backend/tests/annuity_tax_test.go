@@ -0,0 +1,143 @@
+package tests
+
+import (
+	"ferex/backend/calculation"
+	"ferex/backend/models"
+	"ferex/backend/tests/testutils"
+	"testing"
+)
+
+func TestSimplifiedMethod(t *testing.T) {
+	cases := []struct {
+		name           string
+		input          models.SimplifiedMethodInput
+		expectDivisor  int
+		expectMonthly  float64
+		expectFirst    float64
+		expectComplete int
+	}{
+		{
+			name: "Single life, age 57, mid-year start",
+			input: models.SimplifiedMethodInput{
+				EmployeeContributions: 31000,
+				MonthlyAnnuity:        3000,
+				AgeAtAnnuityStart:     57,
+				StartYear:             2025,
+				StartMonth:            7,
+				Years:                 30,
+			},
+			expectDivisor:  310,
+			expectMonthly:  100,
+			expectFirst:    600,
+			expectComplete: 2051, // $600 in 2025, $1,200 a year after, last $400 in 2051
+		},
+		{
+			name: "Joint life with combined ages 122",
+			input: models.SimplifiedMethodInput{
+				EmployeeContributions: 62000,
+				MonthlyAnnuity:        4000,
+				AgeAtAnnuityStart:     62,
+				BeneficiaryAge:        60,
+				StartYear:             2025,
+				StartMonth:            1,
+				Years:                 5,
+			},
+			expectDivisor: 310,
+			expectMonthly: 200,
+			expectFirst:   2400,
+		},
+		{
+			name: "Single life, age 72",
+			input: models.SimplifiedMethodInput{
+				EmployeeContributions: 16000,
+				MonthlyAnnuity:        2000,
+				AgeAtAnnuityStart:     72,
+				StartYear:             2025,
+				Years:                 1,
+			},
+			expectDivisor: 160,
+			expectMonthly: 100,
+			expectFirst:   1200,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := calculation.CalculateSimplifiedMethod(tc.input)
+			if got.Divisor != tc.expectDivisor {
+				t.Errorf("%s: divisor got %d, want %d", tc.name, got.Divisor, tc.expectDivisor)
+			}
+			if testutils.Abs(got.MonthlyTaxFree-tc.expectMonthly) > 0.01 {
+				t.Errorf("%s: monthly tax-free got %.2f, want %.2f", tc.name, got.MonthlyTaxFree, tc.expectMonthly)
+			}
+			if len(got.Schedule) == 0 || testutils.Abs(got.Schedule[0].TaxFree-tc.expectFirst) > 0.01 {
+				t.Fatalf("%s: first-year tax-free wrong: %+v", tc.name, got.Schedule)
+			}
+			if got.RecoveryCompleteYear != tc.expectComplete {
+				t.Errorf("%s: recovery complete got %d, want %d", tc.name, got.RecoveryCompleteYear, tc.expectComplete)
+			}
+		})
+	}
+}
+
+func TestTaxUsesAnnuityTaxFreeAmount(t *testing.T) {
+	withExclusion := calculation.CalculateTax(models.TaxCalculationInput{FilingStatus: "single", GrossPension: 50000, AnnuityTaxFree: 2400})
+	explicit := calculation.CalculateTax(models.TaxCalculationInput{FilingStatus: "single", GrossPension: 50000, TaxablePension: 47600})
+	if testutils.Abs(withExclusion.FederalTaxOwed-explicit.FederalTaxOwed) > 0.01 {
+		t.Errorf("got %.2f, want %.2f", withExclusion.FederalTaxOwed, explicit.FederalTaxOwed)
+	}
+}
+
+func TestCSRSMonthlyTaxFreeAmount(t *testing.T) {
+	got := calculation.CalculateCSRS(models.CSRSCalculationInput{
+		High3Salary:           100000,
+		YearsOfService:        30,
+		AgeAtRetirement:       58,
+		EmployeeContributions: 186000,
+	})
+	if testutils.Abs(got.MonthlyTaxFreeAmount-600) > 0.01 { // $186,000 / 310
+		t.Errorf("got %.2f, want 600.00", got.MonthlyTaxFreeAmount)
+	}
+}
+
+func TestRetirementProjectionTaxesPensionAfterExclusion(t *testing.T) {
+	got := calculation.CalculateRetirementProjection(models.RetirementCalculationInput{
+		CSRSInput: models.CSRSCalculationInput{
+			High3Salary:           100000,
+			YearsOfService:        30,
+			AgeAtRetirement:       58,
+			EmployeeContributions: 186000,
+		},
+		TaxInput:    models.TaxCalculationInput{FilingStatus: "single"},
+		COLAInput:   models.COLACalculationInput{Years: 1, COLAPolicy: "CSRS"},
+		HealthInput: models.HealthPremiumCalculationInput{YearsToProject: 1},
+	})
+	// The $56,250 CSRS annuity is taxed after the $7,200 Simplified Method exclusion
+	expected := calculation.CalculateTax(models.TaxCalculationInput{FilingStatus: "single", TaxablePension: 56250 - 7200})
+	if got.TaxResult.FederalTaxOwed <= 0 || testutils.Abs(got.TaxResult.FederalTaxOwed-expected.FederalTaxOwed) > 0.01 {
+		t.Errorf("federal tax got %.2f, want %.2f", got.TaxResult.FederalTaxOwed, expected.FederalTaxOwed)
+	}
+}
+
+func TestDisabilityAndTransfereeMonthlyTaxFreeAmount(t *testing.T) {
+	disability := calculation.CalculateFERSDisability(models.FERSDisabilityCalculationInput{
+		High3Salary:           100000,
+		YearsOfService:        15,
+		AgeAtDisability:       50,
+		EmployeeContributions: 36000,
+	})
+	if testutils.Abs(disability.MonthlyTaxFreeAmount-100) > 0.01 { // $36,000 / 360
+		t.Errorf("disability got %.2f, want 100.00", disability.MonthlyTaxFreeAmount)
+	}
+	transferee := calculation.CalculateFERSTransferee(models.FERSTransfereeCalculationInput{
+		High3Salary:           100000,
+		CSRSYearsOfService:    10,
+		FERSYearsOfService:    20,
+		AgeAtRetirement:       62,
+		BirthYear:             1962,
+		EmployeeContributions: 52000,
+	})
+	if testutils.Abs(transferee.MonthlyTaxFreeAmount-200) > 0.01 { // $52,000 / 260
+		t.Errorf("transferee got %.2f, want 200.00", transferee.MonthlyTaxFreeAmount)
+	}
+}
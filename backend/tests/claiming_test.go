@@ -0,0 +1,72 @@
+package tests
+
+import (
+	"ferex/backend/calculation"
+	"ferex/backend/models"
+	"testing"
+)
+
+func claimingInput() models.ClaimingStrategyInput {
+	return models.ClaimingStrategyInput{
+		Worker: models.SocialSecurityCalculationInput{
+			BirthYear: 1963, UserProvidedEstimate62: 1750, UserProvidedEstimateFRA: 2500, UserProvidedEstimate70: 3100, ClaimAge: 62,
+		},
+		Spouse: models.SocialSecurityCalculationInput{
+			BirthYear: 1963, UserProvidedEstimate62: 560, UserProvidedEstimateFRA: 800, UserProvidedEstimate70: 992, ClaimAge: 62,
+		},
+		DiscountRate:         0.02,
+		WorkerLifeExpectancy: 88,
+		SpouseLifeExpectancy: 90,
+	}
+}
+
+func TestOptimizeClaimingStrategy(t *testing.T) {
+	got := calculation.OptimizeClaimingStrategy(claimingInput())
+	if got.StrategiesEvaluated != 97*97 {
+		t.Errorf("evaluated %d strategies, want %d", got.StrategiesEvaluated, 97*97)
+	}
+	if len(got.Strategies) != 5 {
+		t.Fatalf("got %d top strategies, want 5", len(got.Strategies))
+	}
+	for i := 1; i < len(got.Strategies); i++ {
+		if got.Strategies[i].ExpectedValue > got.Strategies[i-1].ExpectedValue {
+			t.Errorf("strategies not ranked: %d above %d", i, i-1)
+		}
+	}
+	best := got.Strategies[0]
+	if best.GainOverBaseline <= 0 {
+		t.Errorf("best strategy should beat both claiming at 62, gain %.2f", best.GainOverBaseline)
+	}
+	// With long lives and a survivor benefit at stake, the higher earner delays
+	if best.WorkerClaimAgeMonths < 67*12 {
+		t.Errorf("best worker claim at %d months, want at least FRA", best.WorkerClaimAgeMonths)
+	}
+	// Delaying means falling behind 62 claiming first and catching up later
+	if best.BreakEvenAge < 70 || best.BreakEvenAge > 90 {
+		t.Errorf("break-even age got %d, want between 70 and 90", best.BreakEvenAge)
+	}
+	// Claiming at 62 pays more if the worker dies at 70
+	if best.WorkerDiesEarlyValue >= got.Baseline.WorkerDiesEarlyValue+best.GainOverBaseline {
+		t.Errorf("early death should erode the gain: %.2f vs baseline %.2f", best.WorkerDiesEarlyValue, got.Baseline.WorkerDiesEarlyValue)
+	}
+	// The household is worth more than the worker's own benefit alone
+	if got.Baseline.ExpectedValue <= got.SingleValue {
+		t.Errorf("household value %.2f should exceed the single value %.2f", got.Baseline.ExpectedValue, got.SingleValue)
+	}
+	if got.Single.ClaimingAge != 62 {
+		t.Errorf("single result claim age got %d, want 62", got.Single.ClaimingAge)
+	}
+}
+
+func TestOptimizeClaimingStrategyShortLives(t *testing.T) {
+	input := claimingInput()
+	input.MortalityModel = "fixed"
+	input.WorkerLifeExpectancy = 72
+	input.SpouseLifeExpectancy = 72
+	got := calculation.OptimizeClaimingStrategy(input)
+	// Both die at 72: claiming at 62 wins
+	best := got.Strategies[0]
+	if best.WorkerClaimAgeMonths != 62*12 || best.SpouseClaimAgeMonths != 62*12 {
+		t.Errorf("best strategy got %d/%d months, want 744/744", best.WorkerClaimAgeMonths, best.SpouseClaimAgeMonths)
+	}
+}
@@ -0,0 +1,126 @@
+package tests
+
+import (
+	"ferex/backend/calculation"
+	"ferex/backend/models"
+	"ferex/backend/tests/testutils"
+	"testing"
+)
+
+func TestCOAPCalculation(t *testing.T) {
+	cases := []struct {
+		name                 string
+		input                models.COAPCalculationInput
+		expectAward          float64
+		expectRetiree        float64
+		expectFormerSurvivor float64
+		expectCap            bool
+	}{
+		{
+			name: "Percentage of gross",
+			input: models.COAPCalculationInput{
+				PensionType:  "FERS",
+				GrossAnnuity: 40000,
+				Award:        models.CourtOrderAward{AwardType: "percentage", Percentage: 0.5},
+			},
+			expectAward:   20000,
+			expectRetiree: 20000,
+		},
+		{
+			name: "Marital fraction of gross",
+			input: models.COAPCalculationInput{
+				PensionType:       "CSRS",
+				GrossAnnuity:      50000,
+				TotalServiceYears: 30,
+				Award:             models.CourtOrderAward{AwardType: "marital fraction", Percentage: 0.5, MaritalServiceYears: 15},
+			},
+			expectAward:   12500,
+			expectRetiree: 37500,
+		},
+		{
+			name: "Percentage of net with current spouse survivor election",
+			input: models.COAPCalculationInput{
+				PensionType:           "FERS",
+				GrossAnnuity:          40000,
+				CurrentSpouseElection: "max",
+				HealthPremiums:        6000,
+				Award:                 models.CourtOrderAward{AwardType: "percentage", Percentage: 0.4, Basis: "net"},
+			},
+			expectAward:   0.4 * (36000 - 6000),
+			expectRetiree: 36000 - 12000,
+		},
+		{
+			name: "Former spouse full survivor annuity leaves nothing for a current spouse",
+			input: models.COAPCalculationInput{
+				PensionType:              "FERS",
+				GrossAnnuity:             40000,
+				FormerSpouseSurvivorBase: 40000,
+				CurrentSpouseElection:    "max",
+			},
+			expectAward:          0,
+			expectRetiree:        36000,
+			expectFormerSurvivor: 20000,
+			expectCap:            true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := calculation.CalculateCOAP(tc.input)
+			if testutils.Abs(got.FormerSpouseAward-tc.expectAward) > 0.01 {
+				t.Errorf("%s: award got %.2f, want %.2f", tc.name, got.FormerSpouseAward, tc.expectAward)
+			}
+			if testutils.Abs(got.RetireeAnnuity-tc.expectRetiree) > 0.01 {
+				t.Errorf("%s: retiree annuity got %.2f, want %.2f", tc.name, got.RetireeAnnuity, tc.expectRetiree)
+			}
+			if testutils.Abs(got.FormerSpouseSurvivorAnnuity-tc.expectFormerSurvivor) > 0.01 {
+				t.Errorf("%s: former spouse survivor got %.2f, want %.2f", tc.name, got.FormerSpouseSurvivorAnnuity, tc.expectFormerSurvivor)
+			}
+			if got.SurvivorCapApplied != tc.expectCap {
+				t.Errorf("%s: cap applied got %v, want %v", tc.name, got.SurvivorCapApplied, tc.expectCap)
+			}
+		})
+	}
+}
+
+func TestCOAPCurrentSpouseSurvivorCost(t *testing.T) {
+	got := calculation.CalculateCOAP(models.COAPCalculationInput{
+		PensionType:              "CSRS",
+		GrossAnnuity:             40000,
+		FormerSpouseSurvivorBase: 20000,
+		CurrentSpouseElection:    "max",
+	})
+	// Combined base capped at 40000: 90 + 3640 total, former spouse alone 90 + 1640
+	if testutils.Abs(got.SurvivorReduction-3730) > 0.01 {
+		t.Errorf("survivor reduction got %.2f, want 3730.00", got.SurvivorReduction)
+	}
+	if testutils.Abs(got.CurrentSpouseSurvivorCost-2000) > 0.01 {
+		t.Errorf("current spouse cost got %.2f, want 2000.00", got.CurrentSpouseSurvivorCost)
+	}
+}
+
+func TestCOAPFixedAwardWithoutCOLA(t *testing.T) {
+	got := calculation.CalculateCOAP(models.COAPCalculationInput{
+		PensionType:     "FERS",
+		GrossAnnuity:    30000,
+		Award:           models.CourtOrderAward{AwardType: "fixed", FixedMonthlyAmount: 1000},
+		COLARate:        0.02,
+		ProjectionYears: 3,
+	})
+	for i, award := range got.YearlyFormerSpouseAward {
+		if testutils.Abs(award-12000) > 0.01 {
+			t.Errorf("year %d: award got %.2f, want 12000", i, award)
+		}
+	}
+	if testutils.Abs(got.YearlyRetireeAnnuity[2]-(30000*1.02*1.02-12000)) > 0.01 {
+		t.Errorf("year 3 retiree share got %.2f, want %.2f", got.YearlyRetireeAnnuity[2], 30000*1.02*1.02-12000)
+	}
+}
+
+func TestTaxExcludesFormerSpouseAward(t *testing.T) {
+	withAward := calculation.CalculateTax(models.TaxCalculationInput{FilingStatus: "single", GrossPension: 50000, FormerSpouseAward: 10000})
+	explicit := calculation.CalculateTax(models.TaxCalculationInput{FilingStatus: "single", GrossPension: 50000, TaxablePension: 40000})
+	if testutils.Abs(withAward.FederalTaxOwed-explicit.FederalTaxOwed) > 0.01 {
+		t.Errorf("got %.2f, want %.2f", withAward.FederalTaxOwed, explicit.FederalTaxOwed)
+	}
+}
@@ -96,12 +96,59 @@ func TestCSRSCalculation(t *testing.T) {
 				SurvivorBenefitElection: "max",
 				IsCSRSOffset:            false,
 			},
-			expect:            100000 * (5*0.015 + 5*0.0175 + 20*0.02) * 0.9, // 10% reduction
+			expect:            56250 - (3600*0.025 + (56250-3600)*0.10), // 2.5% of first $3,600 + 10% of the rest
 			offsetReduction:   0,
-			survivorReduction: 100000 * (5*0.015 + 5*0.0175 + 20*0.02) * 0.1,
+			survivorReduction: 3600*0.025 + (56250-3600)*0.10,
 			prorationApplied:  false,
 			notesContains:     "Maximum survivor benefit reduction",
 		},
+		{
+			name: "CSRS partial survivor on a $20,000 base",
+			input: models.CSRSCalculationInput{
+				High3Salary:             100000,
+				YearsOfService:          30,
+				PartTimeProrationFactor: 1.0,
+				SurvivorBenefitElection: "partial",
+				SurvivorBaseAmount:      20000,
+			},
+			expect:            56250 - (90 + 1640),
+			offsetReduction:   0,
+			survivorReduction: 90 + 1640,
+			prorationApplied:  false,
+			notesContains:     "CSRS partial",
+		},
+		{
+			name: "CSRS voluntary early retirement at 52 and 6 months",
+			input: models.CSRSCalculationInput{
+				High3Salary:             100000,
+				YearsOfService:          30,
+				PartTimeProrationFactor: 1.0,
+				SurvivorBenefitElection: "none",
+				AgeAtRetirement:         52,
+				AgeAtRetirementMonths:   6,
+			},
+			expect:            56250 * (1 - 0.05), // 30 months under 55 at 1/6 of 1% per month
+			offsetReduction:   0,
+			survivorReduction: 0,
+			prorationApplied:  false,
+			notesContains:     "Early retirement reduction",
+		},
+		{
+			name: "CSRS disability retirement under 55 is not age-reduced",
+			input: models.CSRSCalculationInput{
+				High3Salary:             100000,
+				YearsOfService:          30,
+				PartTimeProrationFactor: 1.0,
+				SurvivorBenefitElection: "none",
+				AgeAtRetirement:         50,
+				RetirementOption:        "Disability",
+			},
+			expect:            56250,
+			offsetReduction:   0,
+			survivorReduction: 0,
+			prorationApplied:  false,
+			notesContains:     "",
+		},
 		{
 			name: "CSRS Offset (option 1 lower)",
 			input: models.CSRSCalculationInput{
@@ -167,3 +214,44 @@ func TestCSRSCalculation(t *testing.T) {
 		})
 	}
 }
+
+func TestCSRSMilitaryRemovedAt62(t *testing.T) {
+	// Four years of military service without a deposit, credited for a pre-10/1/82 hire
+	history := []models.ServicePeriod{
+		{StartDate: date(1970, 1, 1), EndDate: date(1973, 12, 31), ServiceType: "military"},
+		{StartDate: date(1974, 1, 1), EndDate: date(2003, 12, 31), ServiceType: "fulltime"},
+	}
+	with := 100000 * (5*0.015 + 5*0.0175 + 24*0.02)    // 34 years: $64,250
+	without := 100000 * (5*0.015 + 5*0.0175 + 20*0.02) // 30 years: $56,250
+	cases := []struct {
+		name          string
+		age           int
+		eligibleForSS bool
+		expect        float64
+		expectAt62    float64
+		expectRemoved float64
+	}{
+		{name: "Retiring at 56, eligible for Social Security", age: 56, eligibleForSS: true, expect: with, expectAt62: without, expectRemoved: 4},
+		{name: "Retiring at 56, not eligible for Social Security", age: 56, expect: with, expectAt62: with},
+		{name: "Retiring at 62, eligible for Social Security", age: 62, eligibleForSS: true, expect: without, expectAt62: without, expectRemoved: 4},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := calculation.CalculateCSRS(models.CSRSCalculationInput{
+				High3Salary:               100000,
+				AgeAtRetirement:           c.age,
+				ServiceHistory:            history,
+				EligibleForSocialSecurity: c.eligibleForSS,
+			})
+			if testutils.Abs(got.AnnualPension-c.expect) > 0.01 {
+				t.Errorf("annual pension: got %.2f, want %.2f (%s)", got.AnnualPension, c.expect, got.Notes)
+			}
+			if testutils.Abs(got.AnnualPensionAt62-c.expectAt62) > 0.01 {
+				t.Errorf("annual pension at 62: got %.2f, want %.2f (%s)", got.AnnualPensionAt62, c.expectAt62, got.Notes)
+			}
+			if testutils.Abs(got.MilitaryRemovedAt62Years-c.expectRemoved) > 0.01 {
+				t.Errorf("military removed: got %.2f, want %.2f", got.MilitaryRemovedAt62Years, c.expectRemoved)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package tests
+
+import (
+	"ferex/backend/calculation"
+	"ferex/backend/models"
+	"ferex/backend/tests/testutils"
+	"testing"
+)
+
+func TestFERSDeferredAnnuity(t *testing.T) {
+	input := models.FERSDeferredCalculationInput{
+		FERSInput: models.FERSCalculationInput{
+			High3Salary:           80000,
+			YearsOfService:        12,
+			UnusedSickLeaveMonths: 6,
+			BirthYear:             1975,
+			BirthMonth:            6,
+		},
+		SeparationDate: date(2020, 3, 1),
+	}
+	got := calculation.CalculateFERSDeferred(input)
+
+	if got.AgeAtSeparation != 44 {
+		t.Errorf("age at separation got %d, want 44", got.AgeAtSeparation)
+	}
+	if got.EarliestCommencementAge != 57 || got.UnreducedCommencementAge != 62 {
+		t.Errorf("commencement ages got %d/%d, want 57/62", got.EarliestCommencementAge, got.UnreducedCommencementAge)
+	}
+	// Earliest start at MRA: 5 years under 62, sick leave not credited
+	if testutils.Abs(got.AnnualAnnuity-80000*12*0.01*0.75) > 0.01 {
+		t.Errorf("annuity at MRA got %.2f, want %.2f", got.AnnualAnnuity, 80000*12*0.01*0.75)
+	}
+	if len(got.CommencementTable) != 6 {
+		t.Fatalf("expected 6 commencement ages, got %d", len(got.CommencementTable))
+	}
+	last := got.CommencementTable[5]
+	if last.CommencementAge != 62 || last.ReductionPercent != 0 || testutils.Abs(last.AnnualAnnuity-9600) > 0.01 {
+		t.Errorf("age 62 option got %+v, want unreduced 9600", last)
+	}
+	if got.SRSPayable || got.FEHBContinues {
+		t.Errorf("deferred annuity should lose SRS and FEHB, got SRS=%v FEHB=%v", got.SRSPayable, got.FEHBContinues)
+	}
+}
+
+func TestFERSPostponedMRA10Waiver(t *testing.T) {
+	input := models.FERSDeferredCalculationInput{
+		FERSInput: models.FERSCalculationInput{
+			High3Salary:    80000,
+			YearsOfService: 22,
+			BirthYear:      1967,
+			BirthMonth:     1,
+		},
+		SeparationDate:  date(2024, 6, 30),
+		CommencementAge: 60,
+	}
+	got := calculation.CalculateFERSDeferred(input)
+
+	if got.RetirementType != "MRA+10 Postponed" {
+		t.Errorf("type got %q, want MRA+10 Postponed", got.RetirementType)
+	}
+	if got.UnreducedCommencementAge != 60 {
+		t.Errorf("unreduced age got %d, want 60", got.UnreducedCommencementAge)
+	}
+	if testutils.Abs(got.AnnualAnnuity-17600) > 0.01 || got.ReductionPercent != 0 {
+		t.Errorf("annuity at 60 got %.2f (reduction %.2f), want 17600 unreduced", got.AnnualAnnuity, got.ReductionPercent)
+	}
+	for _, option := range got.CommencementTable {
+		if option.CommencementAge == 58 && testutils.Abs(option.ReductionPercent-0.20) > 0.0001 {
+			t.Errorf("reduction at 58 got %.4f, want 0.20", option.ReductionPercent)
+		}
+	}
+	if !got.FEHBContinues {
+		t.Errorf("postponed MRA+10 should allow FEHB reinstatement")
+	}
+}
+
+func TestFERSDeferredImmediateEligibleSeparation(t *testing.T) {
+	cases := []struct {
+		name         string
+		service      float64
+		separation   models.FERSDeferredCalculationInput
+		expectType   string
+		expectAge    int
+		expectAnnual float64
+	}{
+		// MRA+30 at 57: immediate annuity with 12 months of sick leave credited
+		{"MRA+30", 30, models.FERSDeferredCalculationInput{SeparationDate: date(2032, 6, 30)}, "MRA+30", 57, 80000 * 31 * 0.01},
+		// Separating at 64 is an immediate 62+5 retirement
+		{"Over 62", 10, models.FERSDeferredCalculationInput{SeparationDate: date(2039, 6, 30)}, "62+5", 64, 80000 * 11 * 0.01},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := tc.separation
+			input.FERSInput = models.FERSCalculationInput{
+				High3Salary:           80000,
+				YearsOfService:        tc.service,
+				UnusedSickLeaveMonths: 12,
+				BirthYear:             1975,
+				BirthMonth:            1,
+			}
+			got := calculation.CalculateFERSDeferred(input)
+			if got.RetirementType != tc.expectType || got.CommencementAge != tc.expectAge {
+				t.Errorf("got %s at %d, want %s at %d", got.RetirementType, got.CommencementAge, tc.expectType, tc.expectAge)
+			}
+			if testutils.Abs(got.AnnualAnnuity-tc.expectAnnual) > 0.01 {
+				t.Errorf("annuity got %.2f, want %.2f", got.AnnualAnnuity, tc.expectAnnual)
+			}
+			if len(got.CommencementTable) != 1 || got.CommencementTable[0].CommencementAge != tc.expectAge {
+				t.Errorf("commencement table got %+v, want one row at %d", got.CommencementTable, tc.expectAge)
+			}
+			if !got.FEHBContinues {
+				t.Errorf("FEHB should continue into an immediate annuity")
+			}
+		})
+	}
+}
+
+func TestFERSDeferredTo62KeepsOnePercent(t *testing.T) {
+	// Separated at 50 with 22 years: postponing to 62 removes the reduction but does not earn 1.1%
+	got := calculation.CalculateFERSDeferred(models.FERSDeferredCalculationInput{
+		FERSInput: models.FERSCalculationInput{
+			High3Salary:    100000,
+			YearsOfService: 22,
+			BirthYear:      1970,
+			BirthMonth:     1,
+		},
+		SeparationDate:  date(2020, 6, 30),
+		CommencementAge: 62,
+	})
+	if testutils.Abs(got.AnnualAnnuity-22000) > 0.01 {
+		t.Errorf("annuity at 62 got %.2f, want 22000 (1%% x 22 x 100000)", got.AnnualAnnuity)
+	}
+	if len(got.CommencementTable) == 0 {
+		t.Fatalf("no commencement table (%s)", got.Notes)
+	}
+	last := got.CommencementTable[len(got.CommencementTable)-1]
+	if last.CommencementAge != 62 || testutils.Abs(last.AnnualAnnuity-22000) > 0.01 {
+		t.Errorf("age 62 row got %+v, want 22000", last)
+	}
+}
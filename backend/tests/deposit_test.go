@@ -0,0 +1,155 @@
+package tests
+
+import (
+	"ferex/backend/calculation"
+	"ferex/backend/models"
+	"ferex/backend/tests/testutils"
+	"math"
+	"testing"
+)
+
+func TestCSRSDepositReductions(t *testing.T) {
+	cases := []struct {
+		name              string
+		period            models.ServicePeriod
+		expectTreatment   string
+		expectReduction   bool
+		expectComputation float64
+	}{
+		{
+			name:              "Unpaid pre-10/1/82 deposit: 10% of amount due",
+			period:            models.ServicePeriod{StartDate: date(1980, 1, 1), EndDate: date(1981, 12, 31), ServiceType: "temporary", BasicPay: 30000},
+			expectTreatment:   "10% reduction",
+			expectReduction:   true,
+			expectComputation: 32,
+		},
+		{
+			name:              "Paid deposit is credited without reduction",
+			period:            models.ServicePeriod{StartDate: date(1980, 1, 1), EndDate: date(1981, 12, 31), ServiceType: "temporary", BasicPay: 30000, DepositPaid: true},
+			expectTreatment:   "credited",
+			expectComputation: 32,
+		},
+		{
+			name:              "Unpaid redeposit for refund before 3/1/91: actuarial reduction",
+			period:            models.ServicePeriod{StartDate: date(1980, 1, 1), EndDate: date(1981, 12, 31), ServiceType: "fulltime", Refunded: true, RefundAmount: 4000, RefundDate: date(1982, 3, 1)},
+			expectTreatment:   "actuarial reduction",
+			expectReduction:   true,
+			expectComputation: 32,
+		},
+		{
+			name:              "Unpaid redeposit for service after 3/1/91 counts for eligibility only",
+			period:            models.ServicePeriod{StartDate: date(1991, 3, 1), EndDate: date(1993, 2, 28), ServiceType: "fulltime", Refunded: true, RefundAmount: 4000},
+			expectTreatment:   "eligibility only",
+			expectComputation: 30,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := models.CSRSCalculationInput{
+				High3Salary:     100000,
+				AgeAtRetirement: 60,
+				ServiceHistory: []models.ServicePeriod{
+					tc.period,
+					{StartDate: date(1995, 1, 1), EndDate: date(2024, 12, 31), ServiceType: "fulltime"},
+				},
+			}
+			got := calculation.CalculateCSRS(input)
+			if len(got.DepositItems) != 1 {
+				t.Fatalf("%s: got %d deposit items, want 1", tc.name, len(got.DepositItems))
+			}
+			item := got.DepositItems[0]
+			if item.Treatment != tc.expectTreatment {
+				t.Errorf("%s: treatment got %q, want %q", tc.name, item.Treatment, tc.expectTreatment)
+			}
+			if (got.DepositReduction > 0) != tc.expectReduction {
+				t.Errorf("%s: deposit reduction got %.2f, want reduction=%v", tc.name, got.DepositReduction, tc.expectReduction)
+			}
+			if testutils.Abs(got.ComputationServiceYears-tc.expectComputation) > 0.001 {
+				t.Errorf("%s: computation service got %.4f, want %.4f", tc.name, got.ComputationServiceYears, tc.expectComputation)
+			}
+			if tc.expectTreatment == "10% reduction" && testutils.Abs(got.DepositReduction-(item.AmountDue+item.Interest)*0.10) > 0.01 {
+				t.Errorf("%s: reduction got %.2f, want 10%% of %.2f", tc.name, got.DepositReduction, item.AmountDue+item.Interest)
+			}
+			if item.IsEstimate != (tc.expectTreatment == "actuarial reduction") {
+				t.Errorf("%s: estimate flag got %v", tc.name, item.IsEstimate)
+			}
+		})
+	}
+}
+
+func TestCSRSDepositInterestByEra(t *testing.T) {
+	cases := []struct {
+		name           string
+		period         models.ServicePeriod
+		expectDue      float64
+		expectInterest float64
+	}{
+		{
+			// 7% of pay; interest from the 12/31/1976 midpoint at 3% compounded each December 31 through 2024,
+			// with no switch to the variable rate for service before 10/1/1982
+			name:           "1970s deposit stays at 3%",
+			period:         models.ServicePeriod{StartDate: date(1976, 1, 1), EndDate: date(1977, 12, 31), ServiceType: "temporary", BasicPay: 20000},
+			expectDue:      1400,
+			expectInterest: 1400*(1+0.03/366)*math.Pow(1.03, 48) - 1400,
+		},
+		{
+			name:      "October 1956 service is still in the 6% era",
+			period:    models.ServicePeriod{StartDate: date(1956, 10, 1), EndDate: date(1956, 10, 31), ServiceType: "temporary", BasicPay: 1000},
+			expectDue: 60,
+		},
+		{
+			name:      "November 1956 service is in the 6.5% era",
+			period:    models.ServicePeriod{StartDate: date(1956, 11, 1), EndDate: date(1956, 11, 30), ServiceType: "temporary", BasicPay: 1000},
+			expectDue: 65,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := calculation.CalculateCSRS(models.CSRSCalculationInput{
+				High3Salary:     100000,
+				AgeAtRetirement: 60,
+				ServiceHistory: []models.ServicePeriod{
+					tc.period,
+					{StartDate: date(1995, 1, 1), EndDate: date(2024, 12, 31), ServiceType: "fulltime"},
+				},
+			})
+			if len(got.DepositItems) != 1 {
+				t.Fatalf("%s: got %d deposit items, want 1", tc.name, len(got.DepositItems))
+			}
+			item := got.DepositItems[0]
+			if testutils.Abs(item.AmountDue-tc.expectDue) > 0.01 {
+				t.Errorf("%s: amount due got %.2f, want %.2f", tc.name, item.AmountDue, tc.expectDue)
+			}
+			if tc.expectInterest > 0 && testutils.Abs(item.Interest-tc.expectInterest) > 0.01 {
+				t.Errorf("%s: interest got %.2f, want %.2f", tc.name, item.Interest, tc.expectInterest)
+			}
+		})
+	}
+}
+
+func TestFERSDepositItems(t *testing.T) {
+	input := models.FERSCalculationInput{
+		High3Salary:     100000,
+		AgeAtRetirement: 62,
+		BirthYear:       1962,
+		ServiceHistory: []models.ServicePeriod{
+			{StartDate: date(1986, 1, 1), EndDate: date(1987, 12, 31), ServiceType: "temporary", BasicPay: 40000, DepositPaid: true},
+			{StartDate: date(1989, 1, 1), EndDate: date(1989, 12, 31), ServiceType: "temporary", BasicPay: 20000},
+			{StartDate: date(1995, 1, 1), EndDate: date(2024, 12, 31), ServiceType: "fulltime"},
+		},
+	}
+	got := calculation.CalculateFERSPension(input)
+	if len(got.DepositItems) != 2 {
+		t.Fatalf("got %d deposit items, want 2", len(got.DepositItems))
+	}
+	if got.DepositItems[0].Treatment != "credited" || testutils.Abs(got.DepositItems[0].AmountDue-40000*0.013) > 0.01 {
+		t.Errorf("pre-1989 deposit: got %q $%.2f, want credited $520.00", got.DepositItems[0].Treatment, got.DepositItems[0].AmountDue)
+	}
+	if got.DepositItems[1].Treatment != "not creditable" {
+		t.Errorf("post-1988 non-deduction service: got %q, want not creditable", got.DepositItems[1].Treatment)
+	}
+	if testutils.Abs(got.ComputationServiceYears-32) > 0.001 {
+		t.Errorf("computation service got %.4f, want 32", got.ComputationServiceYears)
+	}
+}
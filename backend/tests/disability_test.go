@@ -0,0 +1,60 @@
+package tests
+
+import (
+	"ferex/backend/calculation"
+	"ferex/backend/models"
+	"ferex/backend/tests/testutils"
+	"math"
+	"testing"
+)
+
+func TestFERSDisabilityCalculation(t *testing.T) {
+	input := models.FERSDisabilityCalculationInput{
+		High3Salary:         90000,
+		YearsOfService:      15,
+		AgeAtDisability:     50,
+		SSDisabilityBenefit: 12000,
+		COLARate:            0.02,
+		YearsToProject:      15,
+	}
+	got := calculation.CalculateFERSDisability(input)
+
+	if len(got.YearlyAnnuity) != 15 {
+		t.Fatalf("expected 15 projected years, got %d", len(got.YearlyAnnuity))
+	}
+	// First 12 months: 60% of High-3 less 100% of SS
+	if testutils.Abs(got.FirstYearAnnuity-(90000*0.6-12000)) > 0.01 {
+		t.Errorf("first year got %.2f, want %.2f", got.FirstYearAnnuity, 90000*0.6-12000)
+	}
+	// After 12 months: 40% of High-3 (with COLA) less 60% of SS
+	want := 90000*0.4*1.02 - 12000*0.6
+	if testutils.Abs(got.YearlyAnnuity[1].Annuity-want) > 0.01 || got.YearlyAnnuity[1].Phase != "40%" {
+		t.Errorf("second year got %.2f (%s), want %.2f (40%%)", got.YearlyAnnuity[1].Annuity, got.YearlyAnnuity[1].Phase, want)
+	}
+	// At 62: disability years credited and High-3 inflated by the COLAs paid
+	recomputedHigh3 := 90000 * math.Pow(1.02, 12)
+	if testutils.Abs(got.RecomputedHigh3-recomputedHigh3) > 0.01 {
+		t.Errorf("recomputed High-3 got %.2f, want %.2f", got.RecomputedHigh3, recomputedHigh3)
+	}
+	if got.RecomputedServiceYears != 27 {
+		t.Errorf("recomputed service got %.2f, want 27", got.RecomputedServiceYears)
+	}
+	at62 := got.YearlyAnnuity[12]
+	if at62.Age != 62 || at62.Phase != "Recomputed" || testutils.Abs(at62.Annuity-recomputedHigh3*27*0.011) > 0.01 {
+		t.Errorf("age 62 got %.2f (%s, age %d), want %.2f", at62.Annuity, at62.Phase, at62.Age, recomputedHigh3*27*0.011)
+	}
+}
+
+func TestFERSDisabilityEarnedAnnuityHigher(t *testing.T) {
+	got := calculation.CalculateFERSDisability(models.FERSDisabilityCalculationInput{
+		High3Salary:         100000,
+		YearsOfService:      45,
+		AgeAtDisability:     60,
+		SSDisabilityBenefit: 30000,
+		YearsToProject:      2,
+	})
+	// 40% of High-3 less 60% of SS is $22,000; the earned annuity of $45,000 is paid instead
+	if got.YearlyAnnuity[1].Phase != "Earned" || testutils.Abs(got.YearlyAnnuity[1].Annuity-45000) > 0.01 {
+		t.Errorf("got %.2f (%s), want 45000.00 (Earned)", got.YearlyAnnuity[1].Annuity, got.YearlyAnnuity[1].Phase)
+	}
+}
@@ -0,0 +1,164 @@
+package tests
+
+import (
+	"ferex/backend/calculation"
+	"ferex/backend/models"
+	"ferex/backend/tests/testutils"
+	"testing"
+)
+
+func TestMRALookup(t *testing.T) {
+	cases := []struct {
+		birthYear int
+		years     int
+		months    int
+	}{
+		{1945, 55, 0},
+		{1950, 55, 6},
+		{1960, 56, 0},
+		{1966, 56, 4},
+		{1975, 57, 0},
+	}
+	for _, tc := range cases {
+		years, months := calculation.GetMRA(tc.birthYear)
+		if years != tc.years || months != tc.months {
+			t.Errorf("MRA for %d: got %d/%d, want %d/%d", tc.birthYear, years, months, tc.years, tc.months)
+		}
+	}
+}
+
+func TestFERSEligibility(t *testing.T) {
+	cases := []struct {
+		name          string
+		input         models.FERSEligibilityInput
+		expectType    string
+		expectReduce  float64
+		expectHigher  bool
+		expectSRS     bool
+		notesContains string
+	}{
+		{
+			name:       "MRA+30 at 57",
+			input:      models.FERSEligibilityInput{BirthYear: 1970, BirthMonth: 3, AgeAtRetirement: 57, YearsOfService: 30},
+			expectType: "MRA+30",
+			expectSRS:  true,
+		},
+		{
+			name:       "60+20",
+			input:      models.FERSEligibilityInput{BirthYear: 1965, AgeAtRetirement: 60, YearsOfService: 22},
+			expectType: "60+20",
+			expectSRS:  true,
+		},
+		{
+			name:         "62+5 with 20 years gets 1.1%",
+			input:        models.FERSEligibilityInput{BirthYear: 1963, AgeAtRetirement: 62, YearsOfService: 20},
+			expectType:   "62+5",
+			expectHigher: true,
+		},
+		{
+			name:         "MRA+10 immediate, 5 years under 62",
+			input:        models.FERSEligibilityInput{BirthYear: 1970, AgeAtRetirement: 57, YearsOfService: 15},
+			expectType:   "MRA+10",
+			expectReduce: 0.25,
+		},
+		{
+			name:         "MRA+10 month-precise age",
+			input:        models.FERSEligibilityInput{BirthYear: 1968, BirthMonth: 1, RetirementYear: 2025, RetirementMonth: 7, YearsOfService: 15},
+			expectType:   "MRA+10",
+			expectReduce: 0.05 * 4.5, // 57y6m: 54 months under 62
+		},
+		{
+			name:          "MRA+10 postponed to 60 with 20 years is unreduced",
+			input:         models.FERSEligibilityInput{BirthYear: 1970, AgeAtRetirement: 57, YearsOfService: 25, AnnuityStartAge: 60},
+			expectType:    "MRA+10 Postponed",
+			notesContains: "waived",
+		},
+		{
+			name:       "VERA at 50 with 20 years, SRS at MRA",
+			input:      models.FERSEligibilityInput{BirthYear: 1975, AgeAtRetirement: 50, YearsOfService: 20, RetirementOption: "VERA"},
+			expectType: "VERA",
+			expectSRS:  true,
+		},
+		{
+			name:       "Deferred before MRA commences at 62",
+			input:      models.FERSEligibilityInput{BirthYear: 1980, AgeAtRetirement: 45, YearsOfService: 12},
+			expectType: "Deferred",
+		},
+		{
+			name:       "Disability",
+			input:      models.FERSEligibilityInput{BirthYear: 1980, AgeAtRetirement: 45, YearsOfService: 12, RetirementOption: "Disability"},
+			expectType: "Disability",
+		},
+		{
+			name:          "Missing birth year tests MRA+30 at 57",
+			input:         models.FERSEligibilityInput{AgeAtRetirement: 56, YearsOfService: 30},
+			expectType:    "Deferred",
+			notesContains: "MRA cannot be determined",
+		},
+		{
+			name:       "Deferred to 62 after separating at 50 with 22 years keeps 1%",
+			input:      models.FERSEligibilityInput{BirthYear: 1970, AgeAtRetirement: 50, YearsOfService: 22, AnnuityStartAge: 62},
+			expectType: "Deferred",
+		},
+		{
+			name:       "Ineligible with under 5 years",
+			input:      models.FERSEligibilityInput{BirthYear: 1980, AgeAtRetirement: 45, YearsOfService: 3},
+			expectType: "Ineligible",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := calculation.DetermineFERSEligibility(tc.input)
+			if got.RetirementType != tc.expectType {
+				t.Errorf("%s: type got %q, want %q", tc.name, got.RetirementType, tc.expectType)
+			}
+			if testutils.Abs(got.ReductionPercent-tc.expectReduce) > 0.0001 {
+				t.Errorf("%s: reduction got %.4f, want %.4f", tc.name, got.ReductionPercent, tc.expectReduce)
+			}
+			if got.AppliesHigherFactor != tc.expectHigher {
+				t.Errorf("%s: 1.1%% multiplier got %v, want %v", tc.name, got.AppliesHigherFactor, tc.expectHigher)
+			}
+			if got.SRSPayable != tc.expectSRS {
+				t.Errorf("%s: SRS payable got %v, want %v", tc.name, got.SRSPayable, tc.expectSRS)
+			}
+			if tc.notesContains != "" && !testutils.Contains(got.Notes, tc.notesContains) {
+				t.Errorf("%s: notes missing expected: %q", tc.name, tc.notesContains)
+			}
+		})
+	}
+}
+
+func TestFERSPensionUsesRetirementMonthForMRA(t *testing.T) {
+	// Born 3/1966 (MRA 56 and 4 months): age 56 in 5/2022 is short of the MRA, in 8/2022 past it
+	input := models.FERSCalculationInput{
+		High3Salary:     100000,
+		YearsOfService:  30,
+		AgeAtRetirement: 56,
+		BirthYear:       1966,
+		BirthMonth:      3,
+		RetirementYear:  2022,
+		RetirementMonth: 5,
+	}
+	if got := calculation.CalculateFERSPension(input); got.RetirementType != "Deferred" {
+		t.Errorf("before MRA: type got %q, want Deferred", got.RetirementType)
+	}
+	input.RetirementMonth = 8
+	if got := calculation.CalculateFERSPension(input); got.RetirementType != "MRA+30" {
+		t.Errorf("after MRA: type got %q, want MRA+30", got.RetirementType)
+	}
+}
+
+func TestFERSPensionDeferredTo62UsesOnePercent(t *testing.T) {
+	// Separated at 50 with 22 years: 1.1% requires age 62 at separation, not at commencement
+	got := calculation.CalculateFERSPension(models.FERSCalculationInput{
+		High3Salary:     100000,
+		YearsOfService:  22,
+		AgeAtRetirement: 50,
+		BirthYear:       1970,
+		AnnuityStartAge: 62,
+	})
+	if testutils.Abs(got.AnnualPension-22000) > 0.01 {
+		t.Errorf("annuity got %.2f, want 22000.00 (1%% x 22 x 100000)", got.AnnualPension)
+	}
+}
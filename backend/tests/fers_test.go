@@ -14,17 +14,16 @@ func TestFERSCalculation(t *testing.T) {
 		expect float64
 	}{
 		{
-			name: "Standard FERS (1.0%)",
+			name: "Standard FERS (1.0%), 60+20 unreduced",
 			input: models.FERSCalculationInput{
 				High3Salary:             100000,
 				YearsOfService:          30,
 				AgeAtRetirement:         60,
-				IsAge62With20Years:      false,
 				SurvivorBenefitElection: "none",
 				IsPartTime:              false,
 				PartTimeProrationFactor: 1.0,
 			},
-			expect: 100000 * 30 * 0.01 * 0.9, // $30,000 * 0.9 = $27,000 (10% reduction for age 60)
+			expect: 100000 * 30 * 0.01, // $30,000 (60+20, no age reduction)
 		},
 		{
 			name: "FERS Age 62+ with 20+ years (1.1%)",
@@ -32,7 +31,6 @@ func TestFERSCalculation(t *testing.T) {
 				High3Salary:             100000,
 				YearsOfService:          25,
 				AgeAtRetirement:         65,
-				IsAge62With20Years:      true,
 				SurvivorBenefitElection: "none",
 				IsPartTime:              false,
 				PartTimeProrationFactor: 1.0,
@@ -45,7 +43,6 @@ func TestFERSCalculation(t *testing.T) {
 				High3Salary:             90000,
 				YearsOfService:          20,
 				AgeAtRetirement:         57,
-				IsAge62With20Years:      false,
 				SurvivorBenefitElection: "none",
 				IsPartTime:              false,
 				PartTimeProrationFactor: 1.0,
@@ -62,7 +59,7 @@ func TestFERSCalculation(t *testing.T) {
 				PartTimeProrationFactor: 0.8,
 				SurvivorBenefitElection: "none",
 			},
-			expect: (80000 * 25 * 0.01 * 0.9) * 0.8, // $20,000 * 0.9 * 0.8 = $14,400 (10% reduction for age 60)
+			expect: (80000 * 25 * 0.01) * 0.8, // $20,000 * 0.8 = $16,000 (60+20, no age reduction)
 		},
 		{
 			name: "Sick Leave Credit (6 months)",
@@ -75,7 +72,18 @@ func TestFERSCalculation(t *testing.T) {
 				IsPartTime:              false,
 				PartTimeProrationFactor: 1.0,
 			},
-			expect: 75000 * (20.5) * 0.01 * 0.9, // 10% reduction for age 60
+			expect: 75000 * (20.5) * 0.01, // 60+20, no age reduction
+		},
+		{
+			name: "MRA+30 before 62 (no reduction)",
+			input: models.FERSCalculationInput{
+				High3Salary:             100000,
+				YearsOfService:          30,
+				AgeAtRetirement:         57,
+				BirthYear:               1968,
+				SurvivorBenefitElection: "none",
+			},
+			expect: 100000 * 30 * 0.01, // $30,000
 		},
 		{
 			name: "Max Survivor Benefit (10% reduction)",
@@ -83,7 +91,6 @@ func TestFERSCalculation(t *testing.T) {
 				High3Salary:             100000,
 				YearsOfService:          30,
 				AgeAtRetirement:         62,
-				IsAge62With20Years:      true,
 				SurvivorBenefitElection: "max",
 			},
 			expect: (100000 * 30 * 0.011) * 0.9, // $33,000 * 0.9 = $29,700
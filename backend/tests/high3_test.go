@@ -0,0 +1,97 @@
+package tests
+
+import (
+	"ferex/backend/calculation"
+	"ferex/backend/models"
+	"ferex/backend/tests/testutils"
+	"testing"
+	"time"
+)
+
+func TestHigh3Calculation(t *testing.T) {
+	cases := []struct {
+		name          string
+		input         models.High3CalculationInput
+		expectHigh3   float64
+		expectStart   time.Time
+		expectEnd     time.Time
+		notesContains string
+	}{
+		{
+			name: "Flat salary",
+			input: models.High3CalculationInput{
+				SalaryHistory:  []models.SalaryRate{{EffectiveDate: date(2015, 1, 1), BasePay: 80000, LocalityPercent: 0.25}},
+				RetirementDate: date(2025, 1, 1),
+			},
+			expectHigh3: 100000,
+			expectStart: date(2015, 1, 1),
+			expectEnd:   date(2017, 12, 31),
+		},
+		{
+			name: "Mid-year promotion is weighted by days",
+			input: models.High3CalculationInput{
+				SalaryHistory: []models.SalaryRate{
+					{EffectiveDate: date(2022, 7, 1), AnnualRate: 110000, Reason: "promotion"},
+					{EffectiveDate: date(2018, 1, 1), AnnualRate: 90000},
+				},
+				RetirementDate: date(2025, 1, 1),
+			},
+			expectHigh3: (181*90000.0 + 915*110000.0) / 1096.0,
+			expectStart: date(2022, 1, 1),
+			expectEnd:   date(2024, 12, 31),
+		},
+		{
+			name: "Move to a lower locality keeps the earlier window",
+			input: models.High3CalculationInput{
+				SalaryHistory: []models.SalaryRate{
+					{EffectiveDate: date(2015, 1, 1), BasePay: 100000, LocalityPercent: 0.20},
+					{EffectiveDate: date(2020, 1, 1), BasePay: 100000, LocalityPercent: 0.0, Reason: "locality change"},
+				},
+				RetirementDate: date(2024, 1, 1),
+			},
+			expectHigh3: 120000,
+			expectStart: date(2015, 1, 1),
+			expectEnd:   date(2017, 12, 31),
+		},
+		{
+			name: "Projected January raises",
+			input: models.High3CalculationInput{
+				SalaryHistory:         []models.SalaryRate{{EffectiveDate: date(2024, 3, 1), AnnualRate: 100000}},
+				RetirementDate:        date(2028, 1, 1),
+				ProjectedRaisePercent: 0.02,
+			},
+			expectHigh3:   (365*102000 + 365*104040 + 365*106120.8) / 1095.0,
+			expectStart:   date(2025, 1, 1),
+			expectEnd:     date(2027, 12, 31),
+			notesContains: "3 projected January raises",
+		},
+		{
+			name: "Less than three years of history",
+			input: models.High3CalculationInput{
+				SalaryHistory:  []models.SalaryRate{{EffectiveDate: date(2023, 1, 1), AnnualRate: 70000}},
+				RetirementDate: date(2024, 1, 1),
+			},
+			expectHigh3:   70000,
+			expectStart:   date(2023, 1, 1),
+			expectEnd:     date(2023, 12, 31),
+			notesContains: "Less than three years",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := calculation.CalculateHigh3(tc.input)
+			if testutils.Abs(got.High3Salary-tc.expectHigh3) > 0.01 {
+				t.Errorf("%s: High-3 got %.2f, want %.2f", tc.name, got.High3Salary, tc.expectHigh3)
+			}
+			if !got.WindowStart.Equal(tc.expectStart) || !got.WindowEnd.Equal(tc.expectEnd) {
+				t.Errorf("%s: window got %s to %s, want %s to %s", tc.name,
+					got.WindowStart.Format("2006-01-02"), got.WindowEnd.Format("2006-01-02"),
+					tc.expectStart.Format("2006-01-02"), tc.expectEnd.Format("2006-01-02"))
+			}
+			if tc.notesContains != "" && !testutils.Contains(got.Notes, tc.notesContains) {
+				t.Errorf("%s: notes missing expected: %q", tc.name, tc.notesContains)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package tests
+
+import (
+	"ferex/backend/calculation"
+	"ferex/backend/models"
+	"ferex/backend/tests/testutils"
+	"testing"
+)
+
+func TestHouseholdSpousalBenefit(t *testing.T) {
+	cases := []struct {
+		name          string
+		workerClaim   int
+		spouseClaim   int
+		expectOwn     float64
+		expectSpousal float64
+		expectStart   int
+	}{
+		// Spouse files at 62 but the spousal benefit waits for the worker's claim at 67, unreduced
+		{"Worker files at FRA", 67, 62, 600 * 0.70, 400, 67 * 12},
+		// Both at 62: 36 x 25/36% + 24 x 5/12% = 35% reduction
+		{"Both file at 62", 62, 62, 600 * 0.70, 400 * 0.65, 62 * 12},
+		{"Spouse files at FRA", 62, 67, 600, 400, 67 * 12},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := calculation.CalculateHouseholdSocialSecurity(models.HouseholdSocialSecurityInput{
+				Worker: models.HouseholdMember{BirthYear: 1960, PIA: 2000, ClaimAge: tc.workerClaim},
+				Spouse: models.HouseholdMember{BirthYear: 1960, PIA: 600, ClaimAge: tc.spouseClaim},
+			})
+			if testutils.Abs(got.Spouse.OwnBenefit-tc.expectOwn) > 0.01 {
+				t.Errorf("own benefit got %.2f, want %.2f", got.Spouse.OwnBenefit, tc.expectOwn)
+			}
+			if testutils.Abs(got.Spouse.SpousalBenefit-tc.expectSpousal) > 0.01 {
+				t.Errorf("spousal benefit got %.2f, want %.2f", got.Spouse.SpousalBenefit, tc.expectSpousal)
+			}
+			if got.Spouse.SpousalStartAgeMonths != tc.expectStart {
+				t.Errorf("spousal start got %d, want %d", got.Spouse.SpousalStartAgeMonths, tc.expectStart)
+			}
+			if got.Worker.SpousalBenefit != 0 {
+				t.Errorf("higher earner should get no spousal benefit, got %.2f", got.Worker.SpousalBenefit)
+			}
+		})
+	}
+}
+
+func TestHouseholdSurvivorBenefit(t *testing.T) {
+	cases := []struct {
+		name        string
+		workerClaim int
+		workerDeath int
+		expect      float64
+		notes       string
+	}{
+		// Worker's reduced benefit is $1,400; RIB-LIM allows the larger of that or 82.5% of the PIA
+		{"RIB-LIM after early claim", 62, 75, 1650, "RIB-LIM"},
+		{"Delayed credits pass to the survivor", 70, 80, 2000 * 1.24, ""},
+		// Death before filing at 63; the survivor starts at 64, 32 months before survivor FRA (66 and 8 months)
+		{"Survivor reduction before survivor FRA", 67, 63, 2000 * (1 - 0.285*32/80), ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := calculation.CalculateHouseholdSocialSecurity(models.HouseholdSocialSecurityInput{
+				Worker: models.HouseholdMember{BirthYear: 1960, PIA: 2000, ClaimAge: tc.workerClaim, DeathAge: tc.workerDeath},
+				Spouse: models.HouseholdMember{BirthYear: 1960, PIA: 600, ClaimAge: 67},
+			})
+			if testutils.Abs(got.Spouse.SurvivorBenefit-tc.expect) > 0.01 {
+				t.Errorf("survivor benefit got %.2f, want %.2f", got.Spouse.SurvivorBenefit, tc.expect)
+			}
+			if tc.notes != "" && !testutils.Contains(got.Notes, tc.notes) {
+				t.Errorf("notes missing %q: %q", tc.notes, got.Notes)
+			}
+		})
+	}
+}
+
+func TestHouseholdBenefitsAtDeath(t *testing.T) {
+	input := models.HouseholdSocialSecurityInput{
+		Worker: models.HouseholdMember{BirthYear: 1960, PIA: 2000, ClaimAge: 62, DeathAge: 75},
+		Spouse: models.HouseholdMember{BirthYear: 1960, PIA: 600, ClaimAge: 67},
+	}
+	result := calculation.CalculateHouseholdSocialSecurity(input)
+	worker, spouse := calculation.HouseholdBenefitsAt(input, result, 70*12)
+	if testutils.Abs(worker-1400) > 0.01 || testutils.Abs(spouse-1000) > 0.01 {
+		t.Errorf("both alive: got %.2f/%.2f, want 1400/1000", worker, spouse)
+	}
+	// After the death the worker's benefit stops and the survivor keeps the larger benefit
+	worker, spouse = calculation.HouseholdBenefitsAt(input, result, 76*12)
+	if worker != 0 || testutils.Abs(spouse-1650) > 0.01 {
+		t.Errorf("after death: got %.2f/%.2f, want 0/1650", worker, spouse)
+	}
+}
+
+func TestHouseholdBenefitsAfterTrustFundDepletion(t *testing.T) {
+	input := models.HouseholdSocialSecurityInput{
+		Worker:    models.HouseholdMember{BirthYear: 1960, PIA: 2000, ClaimAge: 62, DeathAge: 75},
+		Spouse:    models.HouseholdMember{BirthYear: 1960, PIA: 600, ClaimAge: 67},
+		TrustFund: models.TrustFundPolicy{DepletionYear: 2034, PayableShare: 0.8},
+	}
+	result := calculation.CalculateHouseholdSocialSecurity(input)
+	// 2033: still scheduled benefits
+	worker, spouse := calculation.HouseholdBenefitsAt(input, result, 73*12)
+	if testutils.Abs(worker-1400) > 0.01 || testutils.Abs(spouse-1000) > 0.01 {
+		t.Errorf("before depletion: got %.2f/%.2f, want 1400/1000", worker, spouse)
+	}
+	// 2034: own and spousal benefits cut to 80%
+	worker, spouse = calculation.HouseholdBenefitsAt(input, result, 74*12)
+	if testutils.Abs(worker-1120) > 0.01 || testutils.Abs(spouse-800) > 0.01 {
+		t.Errorf("after depletion: got %.2f/%.2f, want 1120/800", worker, spouse)
+	}
+	// The survivor benefit is cut too
+	_, spouse = calculation.HouseholdBenefitsAt(input, result, 76*12)
+	if testutils.Abs(spouse-1320) > 0.01 {
+		t.Errorf("survivor after depletion: got %.2f, want 1320", spouse)
+	}
+}
+
+func TestHouseholdRestrictedApplication(t *testing.T) {
+	// Born 1953 (before deemed filing): spousal-only benefit from FRA (66) while delaying to 70
+	input := models.HouseholdSocialSecurityInput{
+		Worker: models.HouseholdMember{BirthYear: 1953, PIA: 2000, ClaimAge: 66},
+		Spouse: models.HouseholdMember{BirthYear: 1953, PIA: 1500, ClaimAge: 70},
+	}
+	result := calculation.CalculateHouseholdSocialSecurity(input)
+	_, spouse := calculation.HouseholdBenefitsAt(input, result, 67*12)
+	if testutils.Abs(spouse-1000) > 0.01 {
+		t.Errorf("restricted spousal benefit got %.2f, want 1000", spouse)
+	}
+	_, spouse = calculation.HouseholdBenefitsAt(input, result, 70*12)
+	if testutils.Abs(spouse-1500*1.32) > 0.01 {
+		t.Errorf("own benefit at 70 got %.2f, want %.2f", spouse, 1500*1.32)
+	}
+}
+
+func TestSurvivorBenefitUsesHouseholdSocialSecurity(t *testing.T) {
+	got := calculation.CalculateSurvivorBenefit(models.SurvivorBenefitCalculationInput{
+		PensionType:       "FERS",
+		InitialAnnuity:    30000,
+		SurvivorElection:  "max",
+		RetireeAgeAtDeath: 80,
+		YearsToProject:    1,
+		IncludeSSSurvivor: true,
+		SocialSecurity: &models.HouseholdSocialSecurityInput{
+			Worker: models.HouseholdMember{BirthYear: 1960, PIA: 2000, ClaimAge: 67},
+			Spouse: models.HouseholdMember{BirthYear: 1960, PIA: 600, ClaimAge: 67},
+		},
+	})
+	// 50% of the unreduced $30,000 annuity plus the worker's $2,000 benefit continued to the survivor
+	if testutils.Abs(got.TotalSurvivorIncome-(15000+24000)) > 0.01 {
+		t.Errorf("survivor income got %.2f, want %.2f", got.TotalSurvivorIncome, 15000.0+24000)
+	}
+}
+
+func TestSurvivorBenefitAppliesTrustFundShareEachYear(t *testing.T) {
+	got := calculation.CalculateSurvivorBenefit(models.SurvivorBenefitCalculationInput{
+		PensionType:       "FERS",
+		InitialAnnuity:    30000,
+		SurvivorElection:  "max",
+		RetireeAgeAtDeath: 70,
+		YearsToProject:    4,
+		IncludeSSSurvivor: true,
+		SocialSecurity: &models.HouseholdSocialSecurityInput{
+			Worker:    models.HouseholdMember{BirthYear: 1960, PIA: 2000, ClaimAge: 67},
+			Spouse:    models.HouseholdMember{BirthYear: 1960, PIA: 600, ClaimAge: 67},
+			TrustFund: models.TrustFundPolicy{DepletionYear: 2033, PayableShare: 0.8},
+		},
+	})
+	// Survivor benefits from 2031 are paid in full until the 2033 depletion, then at 80%
+	want := []float64{15000 + 24000, 15000 + 24000, 15000 + 19200, 15000 + 19200}
+	if len(got.ProjectedAnnuities) != len(want) {
+		t.Fatalf("got %d projected years, want %d", len(got.ProjectedAnnuities), len(want))
+	}
+	for i, w := range want {
+		if testutils.Abs(got.ProjectedAnnuities[i]-w) > 0.01 {
+			t.Errorf("year %d: got %.2f, want %.2f", 2031+i, got.ProjectedAnnuities[i], w)
+		}
+	}
+}
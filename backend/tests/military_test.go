@@ -0,0 +1,115 @@
+package tests
+
+import (
+	"ferex/backend/calculation"
+	"ferex/backend/models"
+	"ferex/backend/tests/testutils"
+	"testing"
+)
+
+func TestMilitaryDepositCalculation(t *testing.T) {
+	cases := []struct {
+		name            string
+		input           models.MilitaryDepositCalculationInput
+		expectYears     float64
+		expectCredited  float64
+		expectDeposit   float64
+		expectInterest  float64
+		expectBreakEven float64
+		notesContains   string
+	}{
+		{
+			name: "FERS deposit paid within the grace period",
+			input: models.MilitaryDepositCalculationInput{
+				RetirementSystem: "FERS",
+				Periods:          []models.MilitaryPayPeriod{{StartDate: date(1990, 1, 1), EndDate: date(1993, 12, 31), BasicPay: 80000}},
+				CivilianHireDate: date(2010, 3, 1),
+				PayoffDate:       date(2011, 3, 1),
+				DepositPaid:      true,
+			},
+			expectYears:    4,
+			expectCredited: 4,
+			expectDeposit:  2400,
+			expectInterest: 0,
+			notesContains:  "interest-free",
+		},
+		{
+			name: "Period spanning the 1999 and 2000 rate eras",
+			input: models.MilitaryDepositCalculationInput{
+				RetirementSystem: "FERS",
+				Periods:          []models.MilitaryPayPeriod{{StartDate: date(1998, 7, 1), EndDate: date(2000, 6, 30), BasicPay: 73100}},
+				CivilianHireDate: date(2001, 1, 1),
+				PayoffDate:       date(2002, 1, 1),
+			},
+			expectYears:    2,
+			expectCredited: 0,
+			expectDeposit:  18400*0.03 + 36500*0.0325 + 1100*0.034 + 17100*0.03,
+			expectInterest: 0,
+			notesContains:  "not creditable under FERS",
+		},
+		{
+			name: "Interest after the grace period and break-even",
+			input: models.MilitaryDepositCalculationInput{
+				RetirementSystem: "FERS",
+				Periods:          []models.MilitaryPayPeriod{{StartDate: date(2005, 1, 1), EndDate: date(2008, 12, 31), BasicPay: 100000}},
+				CivilianHireDate: date(2015, 1, 1),
+				PayoffDate:       date(2019, 1, 1),
+				High3Salary:      100000,
+				AgeAtRetirement:  62,
+			},
+			expectYears:     4,
+			expectCredited:  0,
+			expectDeposit:   3000,
+			expectInterest:  3000 * (1.02*1.02375 - 1), // 2017 and 2018 OPM rates
+			expectBreakEven: 3000 * 1.02 * 1.02375 / 4000,
+			notesContains:   "Interest accrues from 2017-01-01",
+		},
+		{
+			name: "Grace period ending mid-year accrues the 2017 rate for the rest of 2017",
+			input: models.MilitaryDepositCalculationInput{
+				RetirementSystem: "FERS",
+				Periods:          []models.MilitaryPayPeriod{{StartDate: date(2005, 1, 1), EndDate: date(2008, 12, 31), BasicPay: 100000}},
+				CivilianHireDate: date(2015, 7, 1),
+				PayoffDate:       date(2019, 1, 1),
+			},
+			expectYears:    4,
+			expectDeposit:  3000,
+			expectInterest: 3000 * ((1+0.02*184.0/365)*1.02375 - 1),
+		},
+		{
+			name: "No hire date",
+			input: models.MilitaryDepositCalculationInput{
+				RetirementSystem: "FERS",
+				Periods:          []models.MilitaryPayPeriod{{StartDate: date(2005, 1, 1), EndDate: date(2008, 12, 31), BasicPay: 100000}},
+				PayoffDate:       date(2019, 1, 1),
+			},
+			expectYears:   4,
+			expectDeposit: 3000,
+			notesContains: "interest could not be determined",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := calculation.CalculateMilitaryDeposit(tc.input)
+			if testutils.Abs(got.MilitaryYears-tc.expectYears) > 0.001 {
+				t.Errorf("%s: military years got %.4f, want %.4f", tc.name, got.MilitaryYears, tc.expectYears)
+			}
+			if testutils.Abs(got.CreditedYears-tc.expectCredited) > 0.001 {
+				t.Errorf("%s: credited years got %.4f, want %.4f", tc.name, got.CreditedYears, tc.expectCredited)
+			}
+			if testutils.Abs(got.InitialDeposit-tc.expectDeposit) > 0.01 {
+				t.Errorf("%s: deposit got %.2f, want %.2f", tc.name, got.InitialDeposit, tc.expectDeposit)
+			}
+			if testutils.Abs(got.InterestAccrued-tc.expectInterest) > 0.01 {
+				t.Errorf("%s: interest got %.2f, want %.2f", tc.name, got.InterestAccrued, tc.expectInterest)
+			}
+			if testutils.Abs(got.BreakEvenYears-tc.expectBreakEven) > 0.001 {
+				t.Errorf("%s: break-even got %.4f, want %.4f", tc.name, got.BreakEvenYears, tc.expectBreakEven)
+			}
+			if tc.notesContains != "" && !testutils.Contains(got.Notes, tc.notesContains) {
+				t.Errorf("%s: notes missing expected: %q", tc.name, tc.notesContains)
+			}
+		})
+	}
+}
@@ -89,3 +89,36 @@ func TestRunMonteCarlo_Depletion(t *testing.T) {
 		}
 	}
 }
+
+func TestRunMonteCarlo_TrustFundShortfall(t *testing.T) {
+	input := models.MonteCarloInput{
+		NumSimulations:          100,
+		Years:                   20,
+		InitialBalance:          500000,
+		AnnualWithdrawal:        24000,
+		ExpectedReturn:          0.0,
+		ReturnStdDev:            0.0,
+		InflationMean:           0.0,
+		InflationStdDev:         0.0,
+		Seed:                    7,
+		StartYear:               2030,
+		BirthYear:               1965,
+		SocialSecurityIncome:    30000,
+		SocialSecurityStartYear: 2032,
+		TrustFund:               models.TrustFundPolicy{DepletionYear: 2034, PayableShare: 0.5},
+	}
+
+	result := calculation.RunMonteCarlo(input)
+
+	// Scheduled benefits: 20 x 24,000 = 480,000 leaves money at the end
+	if result.BaselineSuccessRate != 1.0 {
+		t.Errorf("Expected 100%% baseline success rate, got %f", result.BaselineSuccessRate)
+	}
+	// From 2034 the portfolio also replaces 15,000 a year and runs out
+	if result.SuccessRate != 0.0 {
+		t.Errorf("Expected 0%% success rate with the shortfall, got %f", result.SuccessRate)
+	}
+	if result.YearlyBalances[0][4] != 500000-5*24000-15000 {
+		t.Errorf("Year 2034 balance got %f, want %f", result.YearlyBalances[0][4], 500000.0-5*24000-15000)
+	}
+}
@@ -0,0 +1,146 @@
+package tests
+
+import (
+	"ferex/backend/calculation"
+	"ferex/backend/models"
+	"ferex/backend/tests/testutils"
+	"testing"
+)
+
+func TestPhasedRetirement(t *testing.T) {
+	cases := []struct {
+		name            string
+		input           models.PhasedRetirementInput
+		expectEligible  bool
+		expectPhased    float64
+		expectSalary    float64
+		expectComposite float64
+	}{
+		{
+			name: "FERS 60 with 30 years, two years phased",
+			input: models.PhasedRetirementInput{
+				PensionType:           "FERS",
+				High3Salary:           100000,
+				YearsOfService:        30,
+				AgeAtPhasedRetirement: 60,
+				BirthYear:             1962,
+				FullTimeSalary:        110000,
+				PhasedYears:           2,
+				FinalHigh3Salary:      105000,
+				COLARate:              0.02,
+			},
+			expectEligible: true,
+			expectPhased:   15000, // 50% of 1% x 30 x 100000
+			expectSalary:   55000,
+			// Phased annuity (no FERS COLA before 62) + 1.1% x 32 x 105000 x 31/32 - 50% x 1.1% x 30 x 105000
+			expectComposite: 15000 + 36960*31.0/32.0 - 17325,
+		},
+		{
+			name: "CSRS 55 with 30 years, one year phased",
+			input: models.PhasedRetirementInput{
+				PensionType:           "CSRS",
+				High3Salary:           80000,
+				YearsOfService:        30,
+				AgeAtPhasedRetirement: 55,
+				FullTimeSalary:        85000,
+				PhasedYears:           1,
+				COLARate:              0.02,
+			},
+			expectEligible:  true,
+			expectPhased:    22500, // 50% of 56.25% x 80000
+			expectSalary:    42500,
+			expectComposite: 22500*1.02 + 46600*30.5/31.0 - 22500,
+		},
+		{
+			name: "FERS 60 with 20 years and sick leave, two years phased",
+			input: models.PhasedRetirementInput{
+				PensionType:           "FERS",
+				High3Salary:           100000,
+				YearsOfService:        20,
+				AgeAtPhasedRetirement: 60,
+				BirthYear:             1964,
+				FullTimeSalary:        100000,
+				PhasedYears:           2,
+				UnusedSickLeaveMonths: 6,
+			},
+			expectEligible: true,
+			expectPhased:   10000, // 50% of 1% x 20 x 100000
+			expectSalary:   50000,
+			// 1.1% on 22 years of service, computed with 22.5 years including sick leave
+			expectComposite: 10000 + 24750*21.0/22.0 - 11000,
+		},
+		{
+			name: "FERS service history and sick leave hours, two years phased",
+			input: models.PhasedRetirementInput{
+				PensionType:           "FERS",
+				High3Salary:           100000,
+				AgeAtPhasedRetirement: 60,
+				BirthYear:             1964,
+				FullTimeSalary:        100000,
+				PhasedYears:           2,
+				UnusedSickLeaveHours:  1044,
+				ServiceHistory: []models.ServicePeriod{
+					{StartDate: date(2005, 1, 1), EndDate: date(2024, 12, 31), ServiceType: "fulltime"},
+				},
+			},
+			expectEligible:  true,
+			expectPhased:    10000, // 20 years from the history
+			expectSalary:    50000,
+			expectComposite: 10000 + 24750*21.0/22.0 - 11000,
+		},
+		{
+			name: "CSRS part-time service history prorates prior service",
+			input: models.PhasedRetirementInput{
+				PensionType:           "CSRS",
+				High3Salary:           80000,
+				AgeAtPhasedRetirement: 55,
+				FullTimeSalary:        85000,
+				PhasedYears:           1,
+				COLARate:              0.02,
+				ServiceHistory: []models.ServicePeriod{
+					{StartDate: date(1995, 1, 1), EndDate: date(2014, 12, 31), ServiceType: "fulltime"},
+					{StartDate: date(2015, 1, 1), EndDate: date(2024, 12, 31), ServiceType: "parttime", ScheduledHoursPerWeek: 20},
+				},
+			},
+			expectEligible: true,
+			// 30 years prorated by 25/30: 50% of 56.25% x 80000 x 25/30
+			expectPhased:    18750,
+			expectSalary:    42500,
+			expectComposite: 18750*1.02 + 46600*(25+0.5)/31.0 - 18750,
+		},
+		{
+			name: "FERS MRA with 25 years is not eligible",
+			input: models.PhasedRetirementInput{
+				PensionType:           "FERS",
+				High3Salary:           90000,
+				YearsOfService:        25,
+				AgeAtPhasedRetirement: 58,
+				BirthYear:             1966,
+				FullTimeSalary:        95000,
+				PhasedYears:           2,
+			},
+			expectEligible: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := calculation.CalculatePhasedRetirement(tc.input)
+			if got.IsEligible != tc.expectEligible {
+				t.Fatalf("%s: eligible got %v, want %v (%s)", tc.name, got.IsEligible, tc.expectEligible, got.Notes)
+			}
+			if testutils.Abs(got.PhasedAnnuity-tc.expectPhased) > 0.01 {
+				t.Errorf("%s: phased annuity got %.2f, want %.2f", tc.name, got.PhasedAnnuity, tc.expectPhased)
+			}
+			if testutils.Abs(got.PhasedSalary-tc.expectSalary) > 0.01 {
+				t.Errorf("%s: phased salary got %.2f, want %.2f", tc.name, got.PhasedSalary, tc.expectSalary)
+			}
+			if testutils.Abs(got.CompositeAnnuity-tc.expectComposite) > 0.01 {
+				t.Errorf("%s: composite annuity got %.2f, want %.2f", tc.name, got.CompositeAnnuity, tc.expectComposite)
+			}
+			if tc.expectEligible && testutils.Abs(got.MentoringHours-got.WorkHoursPerYear*0.2) > 0.01 {
+				t.Errorf("%s: mentoring hours got %.2f, want 20%% of %.2f", tc.name, got.MentoringHours, got.WorkHoursPerYear)
+			}
+		})
+	}
+}
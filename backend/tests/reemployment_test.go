@@ -0,0 +1,86 @@
+package tests
+
+import (
+	"ferex/backend/calculation"
+	"ferex/backend/models"
+	"ferex/backend/tests/testutils"
+	"testing"
+)
+
+func TestReemployedAnnuitant(t *testing.T) {
+	cases := []struct {
+		name               string
+		input              models.ReemployedAnnuitantInput
+		expectFirstPaid    float64
+		expectTotalEarned  float64
+		expectSupplemental float64
+		expectEligible     bool
+	}{
+		{
+			name: "CSRS salary offset, three years",
+			input: models.ReemployedAnnuitantInput{
+				PensionType:   "CSRS",
+				AnnualSalary:  80000,
+				AnnualAnnuity: 30000,
+				StartAge:      60,
+				EndAge:        62,
+			},
+			expectFirstPaid:    50000,
+			expectTotalEarned:  150000,
+			expectSupplemental: 80000 * 3 * 0.015, // Tiered formula on 3 years at the average salary
+			expectEligible:     true,
+		},
+		{
+			name: "Offset waived: full salary, no supplemental annuity",
+			input: models.ReemployedAnnuitantInput{
+				PensionType:   "FERS",
+				AnnualSalary:  70000,
+				AnnualAnnuity: 25000,
+				StartAge:      58,
+				EndAge:        59,
+				OffsetWaived:  true,
+			},
+			expectFirstPaid:   70000,
+			expectTotalEarned: 140000,
+		},
+		{
+			name: "FERS annuity larger than salary",
+			input: models.ReemployedAnnuitantInput{
+				PensionType:   "FERS",
+				AnnualSalary:  20000,
+				AnnualAnnuity: 30000,
+				StartAge:      63,
+				EndAge:        63,
+			},
+			expectFirstPaid:    0,
+			expectTotalEarned:  0,
+			expectSupplemental: 20000 * 0.01,
+			expectEligible:     true, // One year of reemployment is enough
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := calculation.CalculateReemployedAnnuitant(tc.input)
+			if len(got.Years) == 0 {
+				t.Fatalf("%s: no reemployment years (%s)", tc.name, got.Notes)
+			}
+			if testutils.Abs(got.Years[0].SalaryPaid-tc.expectFirstPaid) > 0.01 {
+				t.Errorf("%s: first-year salary paid got %.2f, want %.2f", tc.name, got.Years[0].SalaryPaid, tc.expectFirstPaid)
+			}
+			if testutils.Abs(got.TotalEarnedIncome-tc.expectTotalEarned) > 0.01 {
+				t.Errorf("%s: total earned got %.2f, want %.2f", tc.name, got.TotalEarnedIncome, tc.expectTotalEarned)
+			}
+			if testutils.Abs(got.SupplementalAnnuity-tc.expectSupplemental) > 0.01 {
+				t.Errorf("%s: supplemental annuity got %.2f, want %.2f", tc.name, got.SupplementalAnnuity, tc.expectSupplemental)
+			}
+			if got.SupplementalEligible != tc.expectEligible {
+				t.Errorf("%s: supplemental eligible got %v, want %v", tc.name, got.SupplementalEligible, tc.expectEligible)
+			}
+			// The last year's flag and the result use the same full year of service
+			if last := got.Years[len(got.Years)-1]; last.SupplementalEligible != got.SupplementalEligible {
+				t.Errorf("%s: last-year eligibility %v does not match the result %v", tc.name, last.SupplementalEligible, got.SupplementalEligible)
+			}
+		})
+	}
+}
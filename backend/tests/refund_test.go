@@ -0,0 +1,86 @@
+package tests
+
+import (
+	"ferex/backend/calculation"
+	"ferex/backend/models"
+	"ferex/backend/tests/testutils"
+	"testing"
+)
+
+func TestAlternativeFormOfAnnuity(t *testing.T) {
+	got := calculation.CalculateAlternativeFormOfAnnuity(models.AlternativeFormOfAnnuityInput{
+		PensionType: "CSRS",
+		CSRSInput: models.CSRSCalculationInput{
+			High3Salary:           100000,
+			YearsOfService:        30,
+			AgeAtRetirement:       60,
+			EmployeeContributions: 48000,
+		},
+		HasLifeThreateningAffliction: true,
+	})
+	if !got.IsAvailable {
+		t.Errorf("expected AFA to be available")
+	}
+	if testutils.Abs(got.LumpSum-48000) > 0.01 {
+		t.Errorf("lump sum got %.2f, want 48000", got.LumpSum)
+	}
+	if testutils.Abs(got.ReducedAnnuity-(56250-48000.0/240*12)) > 0.01 { // factor 240 at age 60
+		t.Errorf("reduced annuity got %.2f, want %.2f", got.ReducedAnnuity, 56250-48000.0/240*12)
+	}
+	if !got.IsEstimate || !testutils.Contains(got.Notes, "Estimate") {
+		t.Errorf("AFA reduction should be labeled an estimate")
+	}
+
+	notAvailable := calculation.CalculateAlternativeFormOfAnnuity(models.AlternativeFormOfAnnuityInput{
+		PensionType: "FERS",
+		FERSInput:   models.FERSCalculationInput{High3Salary: 100000, YearsOfService: 30, AgeAtRetirement: 60, BirthYear: 1965, EmployeeContributions: 24000},
+	})
+	if notAvailable.IsAvailable || !testutils.Contains(notAvailable.Notes, "life-threatening") {
+		t.Errorf("expected AFA to be unavailable without a life-threatening affliction")
+	}
+}
+
+func TestRefundComparison(t *testing.T) {
+	base := models.RefundComparisonInput{
+		FERSInput: models.FERSCalculationInput{
+			High3Salary:           80000,
+			YearsOfService:        10,
+			BirthYear:             1985,
+			BirthMonth:            1,
+			EmployeeContributions: 8000,
+		},
+		SeparationDate:  date(2025, 6, 30),
+		CommencementAge: 62,
+		RefundInterest:  1000,
+		TaxRate:         0.22,
+	}
+	cases := []struct {
+		name           string
+		discount       float64
+		mortality      string
+		expectPV       float64
+		expectRefund   float64
+		expectDecision string
+	}{
+		{"No discounting favors the deferred annuity", 0, "fixed", 8000 * 23, 9000 - 220 - 100, "deferred annuity"},
+		{"Heavy discounting favors the refund", 0.15, "fixed", -1, 9000 - 220 - 100, "refund"},
+		{"Gompertz mortality", 0.03, "gompertz", -1, 9000 - 220 - 100, "deferred annuity"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := base
+			input.DiscountRate = tc.discount
+			input.MortalityModel = tc.mortality
+			got := calculation.CompareRefundWithDeferredAnnuity(input)
+			if testutils.Abs(got.RefundNet-tc.expectRefund) > 0.01 {
+				t.Errorf("%s: refund net got %.2f, want %.2f", tc.name, got.RefundNet, tc.expectRefund)
+			}
+			if tc.expectPV >= 0 && testutils.Abs(got.DeferredPresentValue-tc.expectPV) > 0.01 {
+				t.Errorf("%s: present value got %.2f, want %.2f", tc.name, got.DeferredPresentValue, tc.expectPV)
+			}
+			if got.Recommendation != tc.expectDecision {
+				t.Errorf("%s: recommendation got %q, want %q (PV %.2f)", tc.name, got.Recommendation, tc.expectDecision, got.DeferredPresentValue)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package tests
 import (
 	"ferex/backend/calculation"
 	"ferex/backend/models"
+	"ferex/backend/tests/testutils"
 	"testing"
 )
 
@@ -107,3 +108,75 @@ func TestRetirementCalculationIntegration(t *testing.T) {
 		t.Errorf("Notes should not be empty")
 	}
 }
+
+func TestRetirementReemploymentFeedsSRSEarningsTest(t *testing.T) {
+	result := calculation.CalculateRetirementProjection(models.RetirementCalculationInput{
+		FERSInput: models.FERSCalculationInput{
+			High3Salary:     90000,
+			YearsOfService:  30,
+			AgeAtRetirement: 57,
+			BirthYear:       1967,
+			BirthMonth:      1,
+		},
+		SRSInput: models.SRSCalculationInput{
+			EstimatedSocialSecurityAt62: 24000,
+			YearsOfFERSService:          30,
+			RetirementAge:               57,
+		},
+		ReemploymentInput: models.ReemployedAnnuitantInput{
+			PensionType:   "FERS",
+			AnnualSalary:  60000,
+			AnnualAnnuity: 27000,
+			StartAge:      57,
+			EndAge:        58,
+		},
+		COLAInput:   models.COLACalculationInput{InitialAmount: 27000, Years: 1, COLAPolicy: "FERS"},
+		HealthInput: models.HealthPremiumCalculationInput{YearsToProject: 1},
+	})
+	// Salary paid after the offset is 33000: $1 for every $2 over 23400 reduces the 18000 SRS by 4800
+	if result.ReemploymentResult.Years[0].SalaryPaid != 33000 {
+		t.Errorf("salary paid got %.2f, want 33000.00", result.ReemploymentResult.Years[0].SalaryPaid)
+	}
+	if result.SRSResult.EarningsTestReduction != 4800 {
+		t.Errorf("SRS earnings test reduction got %.2f, want 4800.00", result.SRSResult.EarningsTestReduction)
+	}
+}
+
+func TestRetirementTrustFundShortfall(t *testing.T) {
+	result := calculation.CalculateRetirementProjection(models.RetirementCalculationInput{
+		SocialSecurityInput: models.SocialSecurityCalculationInput{
+			BirthYear:               1965,
+			CurrentAge:              60,
+			UserProvidedEstimate62:  1750,
+			UserProvidedEstimateFRA: 2500,
+			UserProvidedEstimate70:  3100,
+			ClaimAge:                67,
+		},
+		COLAInput:   models.COLACalculationInput{InitialAmount: 0, Years: 1, COLAPolicy: "FERS"},
+		HealthInput: models.HealthPremiumCalculationInput{YearsToProject: 1},
+		MonteCarloInput: models.MonteCarloInput{
+			NumSimulations:   50,
+			Years:            30,
+			InitialBalance:   500000,
+			AnnualWithdrawal: 15000,
+			Seed:             7,
+		},
+		TrustFund:      models.TrustFundPolicy{DepletionYear: 2034, PayableShare: 0.77},
+		LifeExpectancy: 85,
+	})
+	// First-year income counts the 2500/month benefit as 30000 a year, paid in full before depletion
+	if result.ScheduledRetirementIncome != 30000 || result.TotalRetirementIncome != 30000 {
+		t.Errorf("first-year income got %.2f scheduled, %.2f payable; want 30000", result.ScheduledRetirementIncome, result.TotalRetirementIncome)
+	}
+	// Claiming in 2032 at 2500/month through 2050: 2 full years, then 17 years at 77%
+	if result.LifetimeScheduledSS != 19*30000 {
+		t.Errorf("scheduled lifetime SS got %.2f, want %.2f", result.LifetimeScheduledSS, 19*30000.0)
+	}
+	if testutils.Abs(result.LifetimePayableSS-(2*30000+17*30000*0.77)) > 0.01 {
+		t.Errorf("payable lifetime SS got %.2f, want %.2f", result.LifetimePayableSS, 2*30000+17*30000*0.77)
+	}
+	// The portfolio covers 15000 plus 6900 of missing benefits from 2034 and runs out before year 30
+	if result.MonteCarloResult.BaselineSuccessRate != 1.0 || result.MonteCarloResult.SuccessRate != 0.0 {
+		t.Errorf("success rate got %.2f, baseline %.2f; want 0 and 1", result.MonteCarloResult.SuccessRate, result.MonteCarloResult.BaselineSuccessRate)
+	}
+}
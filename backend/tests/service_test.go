@@ -0,0 +1,255 @@
+package tests
+
+import (
+	"ferex/backend/calculation"
+	"ferex/backend/models"
+	"ferex/backend/tests/testutils"
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestServiceCreditCalculation(t *testing.T) {
+	cases := []struct {
+		name              string
+		input             models.ServiceHistoryInput
+		expectEligibility float64
+		expectComputation float64
+		expectProration   float64
+		notesContains     string
+	}{
+		{
+			name: "FERS full-time career",
+			input: models.ServiceHistoryInput{
+				RetirementSystem: "FERS",
+				Periods: []models.ServicePeriod{
+					{StartDate: date(1995, 1, 1), EndDate: date(2024, 12, 31), ServiceType: "fulltime"},
+				},
+			},
+			expectEligibility: 30,
+			expectComputation: 30,
+			expectProration:   1.0,
+		},
+		{
+			name: "FERS half-time decade is prorated by hours",
+			input: models.ServiceHistoryInput{
+				RetirementSystem: "FERS",
+				Periods: []models.ServicePeriod{
+					{StartDate: date(2000, 1, 1), EndDate: date(2009, 12, 31), ServiceType: "fulltime"},
+					{StartDate: date(2010, 1, 1), EndDate: date(2019, 12, 31), ServiceType: "parttime", ScheduledHoursPerWeek: 20},
+				},
+			},
+			expectEligibility: 20,
+			expectComputation: 20,
+			expectProration:   0.75, // (10 + 10*0.5) / 20
+			notesContains:     "proration factor",
+		},
+		{
+			name: "FERS military and post-1988 temporary service without deposit",
+			input: models.ServiceHistoryInput{
+				RetirementSystem: "FERS",
+				Periods: []models.ServicePeriod{
+					{StartDate: date(1990, 1, 1), EndDate: date(1993, 12, 31), ServiceType: "military"},
+					{StartDate: date(1994, 1, 1), EndDate: date(1995, 12, 31), ServiceType: "temporary", DepositPaid: true},
+					{StartDate: date(2000, 1, 1), EndDate: date(2019, 12, 31), ServiceType: "fulltime"},
+				},
+			},
+			expectEligibility: 20,
+			expectComputation: 20,
+			expectProration:   1.0,
+			notesContains:     "Military service without deposit",
+		},
+		{
+			name: "FERS military with deposit paid",
+			input: models.ServiceHistoryInput{
+				RetirementSystem: "FERS",
+				Periods: []models.ServicePeriod{
+					{StartDate: date(1990, 1, 1), EndDate: date(1993, 12, 31), ServiceType: "military", DepositPaid: true},
+					{StartDate: date(2000, 1, 1), EndDate: date(2019, 12, 31), ServiceType: "fulltime"},
+				},
+			},
+			expectEligibility: 24,
+			expectComputation: 24,
+			expectProration:   1.0,
+		},
+		{
+			name: "CSRS military without deposit, first hired before 10/1/82",
+			input: models.ServiceHistoryInput{
+				RetirementSystem: "CSRS",
+				Periods: []models.ServicePeriod{
+					{StartDate: date(1970, 1, 1), EndDate: date(1973, 12, 31), ServiceType: "military"},
+					{StartDate: date(1980, 1, 1), EndDate: date(1999, 12, 31), ServiceType: "fulltime"},
+				},
+			},
+			expectEligibility: 24,
+			expectComputation: 24,
+			expectProration:   1.0,
+			notesContains:     "removed at 62",
+		},
+		{
+			name: "CSRS military without deposit, first hired after 9/30/82",
+			input: models.ServiceHistoryInput{
+				RetirementSystem: "CSRS",
+				Periods: []models.ServicePeriod{
+					{StartDate: date(1970, 1, 1), EndDate: date(1973, 12, 31), ServiceType: "military"},
+					{StartDate: date(1983, 1, 1), EndDate: date(2002, 12, 31), ServiceType: "fulltime"},
+				},
+			},
+			expectEligibility: 20,
+			expectComputation: 20,
+			expectProration:   1.0,
+			notesContains:     "Military service without deposit",
+		},
+		{
+			name: "CSRS non-deduction service after 10/1/82 without deposit counts for eligibility only",
+			input: models.ServiceHistoryInput{
+				RetirementSystem: "CSRS",
+				Periods: []models.ServicePeriod{
+					{StartDate: date(1982, 10, 1), EndDate: date(1984, 9, 30), ServiceType: "temporary"},
+					{StartDate: date(1984, 10, 1), EndDate: date(2014, 9, 30), ServiceType: "fulltime"},
+				},
+			},
+			expectEligibility: 32,
+			expectComputation: 30,
+			expectProration:   1.0,
+			notesContains:     "eligibility only",
+		},
+		{
+			name: "CSRS non-deduction service before 10/1/82 without deposit is credited",
+			input: models.ServiceHistoryInput{
+				RetirementSystem: "CSRS",
+				Periods: []models.ServicePeriod{
+					{StartDate: date(1978, 1, 1), EndDate: date(1979, 12, 31), ServiceType: "temporary"},
+					{StartDate: date(1980, 1, 1), EndDate: date(2009, 12, 31), ServiceType: "fulltime"},
+				},
+			},
+			expectEligibility: 32,
+			expectComputation: 32,
+			expectProration:   1.0,
+			notesContains:     "10% reduction",
+		},
+		{
+			name: "Odd days dropped from computation only",
+			input: models.ServiceHistoryInput{
+				RetirementSystem: "FERS",
+				Periods: []models.ServicePeriod{
+					{StartDate: date(2000, 1, 1), EndDate: date(2010, 1, 15), ServiceType: "fulltime"},
+				},
+			},
+			expectEligibility: 10 + 15.0/360.0,
+			expectComputation: 10,
+			expectProration:   1.0,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := calculation.CalculateServiceCredit(tc.input)
+			if testutils.Abs(got.EligibilityYears-tc.expectEligibility) > 0.001 {
+				t.Errorf("%s: eligibility got %.4f, want %.4f", tc.name, got.EligibilityYears, tc.expectEligibility)
+			}
+			if testutils.Abs(got.ComputationYears-tc.expectComputation) > 0.001 {
+				t.Errorf("%s: computation got %.4f, want %.4f", tc.name, got.ComputationYears, tc.expectComputation)
+			}
+			if testutils.Abs(got.ProrationFactor-tc.expectProration) > 0.0001 {
+				t.Errorf("%s: proration got %.4f, want %.4f", tc.name, got.ProrationFactor, tc.expectProration)
+			}
+			if tc.notesContains != "" && !testutils.Contains(got.Notes, tc.notesContains) {
+				t.Errorf("%s: notes missing expected: %q", tc.name, tc.notesContains)
+			}
+		})
+	}
+}
+
+func TestFERSPensionFromServiceHistory(t *testing.T) {
+	input := models.FERSCalculationInput{
+		High3Salary:     100000,
+		AgeAtRetirement: 62,
+		ServiceHistory: []models.ServicePeriod{
+			{StartDate: date(2000, 1, 1), EndDate: date(2009, 12, 31), ServiceType: "fulltime"},
+			{StartDate: date(2010, 1, 1), EndDate: date(2019, 12, 31), ServiceType: "parttime", ScheduledHoursPerWeek: 20},
+		},
+	}
+	got := calculation.CalculateFERSPension(input)
+	want := 100000 * 20 * 0.011 * 0.75
+	if testutils.Abs(got.AnnualPension-want) > 0.01 {
+		t.Errorf("got %.2f, want %.2f", got.AnnualPension, want)
+	}
+	if !got.ProrationApplied {
+		t.Errorf("expected proration to be applied")
+	}
+	if testutils.Abs(got.EligibilityServiceYears-20) > 0.001 {
+		t.Errorf("eligibility service got %.4f, want 20", got.EligibilityServiceYears)
+	}
+}
+
+func TestSickLeaveHoursConversion(t *testing.T) {
+	cases := []struct {
+		name          string
+		input         models.FERSCalculationInput
+		expectSick    models.ServiceDuration
+		expectService models.ServiceDuration
+		expectAnnuity float64
+	}{
+		{
+			name: "Sick leave days combine with odd service days before they are dropped",
+			input: models.FERSCalculationInput{
+				High3Salary:          100000,
+				AgeAtRetirement:      60,
+				BirthYear:            1965,
+				UnusedSickLeaveHours: 1000, // 5 months (870 hours) + 22 days (128 hours)
+				ServiceHistory: []models.ServicePeriod{
+					{StartDate: date(2000, 1, 1), EndDate: date(2029, 3, 20), ServiceType: "fulltime"},
+				},
+			},
+			expectSick:    models.ServiceDuration{Months: 5, Days: 22},
+			expectService: models.ServiceDuration{Years: 29, Months: 8}, // 29y 2m 20d + 5m 22d = 29y 8m 12d
+			expectAnnuity: 100000 * (29 + 8.0/12.0) * 0.01,
+		},
+		{
+			name: "A full 2087 hours is one year",
+			input: models.FERSCalculationInput{
+				High3Salary:          100000,
+				YearsOfService:       20,
+				AgeAtRetirement:      60,
+				BirthYear:            1965,
+				UnusedSickLeaveHours: 2087,
+			},
+			expectSick:    models.ServiceDuration{Years: 1},
+			expectService: models.ServiceDuration{Years: 21},
+			expectAnnuity: 100000 * 21 * 0.01,
+		},
+		{
+			name: "Firefighter 2756-hour chart",
+			input: models.FERSCalculationInput{
+				High3Salary:          100000,
+				YearsOfService:       20,
+				AgeAtRetirement:      60,
+				BirthYear:            1965,
+				UnusedSickLeaveHours: 1378,
+				SickLeaveSchedule:    "firefighter",
+			},
+			expectSick:    models.ServiceDuration{Months: 6},
+			expectService: models.ServiceDuration{Years: 20, Months: 6},
+			expectAnnuity: 100000 * 20.5 * 0.01,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := calculation.CalculateFERSPension(tc.input)
+			if got.SickLeaveCredit != tc.expectSick {
+				t.Errorf("%s: sick leave got %+v, want %+v", tc.name, got.SickLeaveCredit, tc.expectSick)
+			}
+			if got.TotalComputationService != tc.expectService {
+				t.Errorf("%s: total service got %+v, want %+v", tc.name, got.TotalComputationService, tc.expectService)
+			}
+			if testutils.Abs(got.AnnualPension-tc.expectAnnuity) > 0.01 {
+				t.Errorf("%s: annuity got %.2f, want %.2f", tc.name, got.AnnualPension, tc.expectAnnuity)
+			}
+		})
+	}
+}
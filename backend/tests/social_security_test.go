@@ -98,3 +98,349 @@ func TestSocialSecurityCalculation(t *testing.T) {
 		})
 	}
 }
+
+func TestFullRetirementAge(t *testing.T) {
+	cases := []struct {
+		birthYear int
+		years     int
+		months    int
+	}{
+		{1937, 65, 0}, {1938, 65, 2}, {1942, 65, 10}, {1943, 66, 0}, {1954, 66, 0},
+		{1955, 66, 2}, {1957, 66, 6}, {1959, 66, 10}, {1960, 67, 0}, {1975, 67, 0},
+	}
+	for _, tc := range cases {
+		years, months := calculation.FullRetirementAge(tc.birthYear)
+		if years != tc.years || months != tc.months {
+			t.Errorf("%d: FRA got %d/%d, want %d/%d", tc.birthYear, years, months, tc.years, tc.months)
+		}
+	}
+}
+
+func TestSocialSecurityMonthlyClaimingFactors(t *testing.T) {
+	cases := []struct {
+		name        string
+		claimAge    int
+		claimMonths int
+		expect      float64
+	}{
+		{"At FRA 66 and 6 months", 66, 6, 2000},
+		{"42 months early: 36 x 5/9% + 6 x 5/12%", 63, 0, 2000 * (1 - 0.20 - 0.025)},
+		{"12 months early", 65, 6, 2000 * (1 - 12*5.0/900)},
+		{"21 months delayed: 2/3% per month", 68, 3, 2000 * (1 + 21*2.0/300)},
+		{"Delay stops at 70", 71, 0, 2000 * (1 + 42*2.0/300)},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Born 1957: FRA 66 and 6 months; the statement's FRA amount is the PIA
+			got := calculation.CalculateSocialSecurity(models.SocialSecurityCalculationInput{
+				BirthYear:               1957,
+				UserProvidedEstimate62:  1500,
+				UserProvidedEstimateFRA: 2000,
+				UserProvidedEstimate70:  2560,
+				ClaimAge:                tc.claimAge,
+				ClaimAgeMonths:          tc.claimMonths,
+			})
+			if testutils.Abs(got.ClaimingAmount-tc.expect) > 0.01 {
+				t.Errorf("%s: got %.2f, want %.2f", tc.name, got.ClaimingAmount, tc.expect)
+			}
+		})
+	}
+}
+
+func TestSocialSecurityClaimBefore62(t *testing.T) {
+	cases := []struct {
+		name  string
+		input models.SocialSecurityCalculationInput
+	}{
+		{
+			name: "SSA statement values, claim at 60",
+			input: models.SocialSecurityCalculationInput{
+				BirthYear:               1957,
+				UserProvidedEstimate62:  1500,
+				UserProvidedEstimateFRA: 2000,
+				UserProvidedEstimate70:  2560,
+				ClaimAge:                60,
+			},
+		},
+		{
+			name: "Computed from salary, claim at 58 and 6 months",
+			input: models.SocialSecurityCalculationInput{
+				BirthYear:             1960,
+				EstimatedAnnualSalary: 60000,
+				YearsWorked:           35,
+				ClaimAge:              58,
+				ClaimAgeMonths:        6,
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := calculation.CalculateSocialSecurity(tc.input)
+			if got.ClaimingAge != 62 || got.ClaimingAgeMonths != 0 {
+				t.Errorf("%s: claiming age got %d/%d, want 62/0", tc.name, got.ClaimingAge, got.ClaimingAgeMonths)
+			}
+			if got.ClaimingAmount <= 0 || testutils.Abs(got.ClaimingAmount-got.EstimatedAt62) > 0.01 {
+				t.Errorf("%s: claim amount got %.2f, want the amount at 62 %.2f", tc.name, got.ClaimingAmount, got.EstimatedAt62)
+			}
+			if !testutils.Contains(got.Notes, "before 62") {
+				t.Errorf("%s: notes missing the claiming age clamp: %s", tc.name, got.Notes)
+			}
+		})
+	}
+}
+
+func TestSocialSecurityEstimatedAt62UsesExactReduction(t *testing.T) {
+	got := calculation.CalculateSocialSecurity(models.SocialSecurityCalculationInput{
+		BirthYear:             1960,
+		EstimatedAnnualSalary: 60000,
+		YearsWorked:           35,
+		ClaimAge:              62,
+	})
+	// 60 months early: 20% + 24 x 5/12% = 30%
+	if testutils.Abs(got.EstimatedAt62-got.PIA*0.70) > 0.01 {
+		t.Errorf("at 62 got %.2f, want 70%% of %.2f", got.EstimatedAt62, got.PIA)
+	}
+	// COLAs received after 62 (8.7% in 2022, 3.2% in 2023, 2.5% in 2024) raise the later amounts
+	if got.EstimatedAt70 < got.PIA*1.24*1.087*1.032*1.025-1 {
+		t.Errorf("at 70 got %.2f, want at least 124%% of the COLA-adjusted PIA", got.EstimatedAt70)
+	}
+}
+
+func TestPIABendPointsByEligibilityYear(t *testing.T) {
+	cases := []struct {
+		birthYear  int
+		bendPoints []float64
+	}{
+		{1962, []float64{1174, 7078}},
+		{1963, []float64{1226, 7391}},
+	}
+	for _, tc := range cases {
+		got := calculation.CalculateSocialSecurity(models.SocialSecurityCalculationInput{
+			BirthYear:             tc.birthYear,
+			EstimatedAnnualSalary: 50000,
+			YearsWorked:           30,
+		})
+		if len(got.BendPoints) != 2 || got.BendPoints[0] != tc.bendPoints[0] || got.BendPoints[1] != tc.bendPoints[1] {
+			t.Errorf("born %d: got bend points %v, want %v", tc.birthYear, got.BendPoints, tc.bendPoints)
+		}
+	}
+}
+
+func TestAIMEIndexesToAge60AndKeepsTop35(t *testing.T) {
+	awi := map[int]float64{
+		1984: 16135.07, 1985: 16822.51, 1986: 17321.82, 1987: 18426.51, 1988: 19334.04, 1989: 20099.55,
+		1990: 21027.98, 1991: 21811.60, 1992: 22935.42, 1993: 23132.67, 1994: 23753.53, 1995: 24705.66,
+		1996: 25913.90, 1997: 27426.00, 1998: 28861.44, 1999: 30469.84, 2000: 32154.82, 2001: 32921.92,
+		2002: 33252.09, 2003: 34064.95, 2004: 35648.55, 2005: 36952.94, 2006: 38651.41, 2007: 40405.48,
+		2008: 41334.97, 2009: 40711.61, 2010: 41673.83, 2011: 42979.61, 2012: 44321.67, 2013: 44888.16,
+		2014: 46481.52, 2015: 48098.63, 2016: 48642.15, 2017: 50321.89, 2018: 52145.80, 2019: 54099.99,
+		2020: 55628.60, 2021: 60575.07, 2022: 63795.13, 2023: 66621.80,
+	}
+	// Born 1963: indexing year 2023, eligible 2025. Five low years (1979-83) fall out of the top 35,
+	// years earning the AWI index to the 2023 AWI, and 2024 counts at face value.
+	var history []float64
+	for year := 1979; year <= 2024; year++ {
+		switch {
+		case year < 1984:
+			history = append(history, 1000)
+		case year == 2024:
+			history = append(history, 100000)
+		default:
+			history = append(history, awi[year])
+		}
+	}
+	base := models.SocialSecurityCalculationInput{
+		BirthYear:         1963,
+		CurrentAge:        62,
+		EarningsHistory:   history,
+		EarningsStartYear: 1979,
+		ClaimAge:          62,
+	}
+	got := calculation.CalculateSocialSecurity(base)
+	// (100000 + 34 x 66621.80) / 420 = 5631.29
+	if got.AIME != 5631 {
+		t.Errorf("AIME got %.2f, want 5631", got.AIME)
+	}
+	// 90% x 1226 + 32% x (5631 - 1226)
+	if testutils.Abs(got.PIA-2513.0) > 0.01 {
+		t.Errorf("PIA got %.2f, want 2513.00", got.PIA)
+	}
+	if testutils.Abs(got.ClaimingAmount-2513.0*0.70) > 0.01 {
+		t.Errorf("claim at 62 got %.2f, want %.2f", got.ClaimingAmount, 2513.0*0.70)
+	}
+
+	// Claiming at FRA (67) carries the COLAs of December 2025 through 2029
+	base.ClaimAge = 67
+	base.COLARate = 0.02
+	got = calculation.CalculateSocialSecurity(base)
+	want := 2513.0
+	for i := 0; i < 5; i++ {
+		want *= 1.02
+	}
+	if testutils.Abs(got.ClaimingAmount-want) > 1.0 {
+		t.Errorf("claim at 67 got %.2f, want about %.2f", got.ClaimingAmount, want)
+	}
+}
+
+func TestAIMEIncludesProjectedEarnings(t *testing.T) {
+	// Born 1990, 35 in 2025, earning $50,000 growing with wages until 45: each of the 10 years
+	// indexes to $50,000 x 1.03^25 in the 2050 indexing year.
+	got := calculation.CalculateSocialSecurity(models.SocialSecurityCalculationInput{
+		BirthYear:            1990,
+		CurrentAge:           35,
+		FutureAnnualEarnings: 50000,
+		StopWorkingAge:       45,
+		WageGrowthRate:       0.03,
+	})
+	if got.AIME != 2492 {
+		t.Errorf("AIME got %.2f, want 2492", got.AIME)
+	}
+}
+
+func TestEarningsCappedAtTaxableMaximum(t *testing.T) {
+	cases := []struct {
+		name       string
+		input      models.SocialSecurityCalculationInput
+		expectAIME float64
+	}{
+		{
+			// 2025 base $176,100, then the 1994 base x AWI two years before / 1992 AWI, to $300:
+			// $181,200 in 2026 up to $229,800 in 2034, each indexed to 2050
+			name: "Projected earnings above the base",
+			input: models.SocialSecurityCalculationInput{
+				BirthYear:            1990,
+				CurrentAge:           35,
+				FutureAnnualEarnings: 400000,
+				StopWorkingAge:       45,
+				WageGrowthRate:       0.03,
+			},
+			expectAIME: 8774,
+		},
+		{
+			name: "Average salary above today's base",
+			input: models.SocialSecurityCalculationInput{
+				BirthYear:             1960,
+				CurrentAge:            65,
+				EstimatedAnnualSalary: 250000,
+				YearsWorked:           35,
+			},
+			expectAIME: 14675, // 35 x $176,100 / 420
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := calculation.CalculateSocialSecurity(tc.input)
+			if got.AIME != tc.expectAIME {
+				t.Errorf("%s: AIME got %.2f, want %.2f", tc.name, got.AIME, tc.expectAIME)
+			}
+		})
+	}
+}
+
+func TestSocialSecurityEarningsTest(t *testing.T) {
+	cases := []struct {
+		name           string
+		birthYear      int
+		birthMonth     int
+		claimAge       int
+		monthly        float64
+		earned         []float64
+		expectWithheld float64
+		expectMonths   int
+		expectAfterFRA float64
+		colaRate       float64
+	}{
+		// Born January 1963, claims at 62 in 2025: ($43,400 - $23,400) / 2 withheld, 6 months credited back at FRA
+		{"$1 for $2 over the limit", 1963, 1, 62, 1750, []float64{43400}, 10000, 6, 1750 * 0.725 / 0.70, 0},
+		{"Under the limit", 1963, 1, 62, 1750, []float64{20000}, 0, 0, 1750, 0},
+		// Born June 1960, FRA June 2027: half of 2027's $240,000 is earned before FRA; ($100,000 - $62,160) / 3
+		// exceeds the $10,000 of benefits due, so all 5 months are withheld
+		{"$1 for $3 in the FRA year", 1960, 6, 66, 2000, []float64{0, 240000}, 10000, 5, 2000 * (1 - 7*5.0/900) / (1 - 12*5.0/900), 0},
+		// 2026's $5,407.50 withheld is 3 months of the $1,802.50 benefit after a 3% COLA (4 months without it)
+		{"Tested against the benefit with COLAs", 1963, 1, 62, 1750, []float64{20000, 34215}, 5407.50, 3, 1750 * 0.7125 / 0.70, 0.03},
+		{"No test from FRA", 1963, 1, 67, 2500, []float64{200000}, 0, 0, 2500, 0},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := calculation.CalculateSSEarningsTest(models.SSEarningsTestInput{
+				BirthYear:          tc.birthYear,
+				BirthMonth:         tc.birthMonth,
+				ClaimAge:           tc.claimAge,
+				MonthlyBenefit:     tc.monthly,
+				COLARate:           tc.colaRate,
+				YearlyEarnedIncome: tc.earned,
+			})
+			if testutils.Abs(got.TotalWithheld-tc.expectWithheld) > 0.01 {
+				t.Errorf("withheld got %.2f, want %.2f", got.TotalWithheld, tc.expectWithheld)
+			}
+			if got.MonthsWithheld != tc.expectMonths {
+				t.Errorf("months withheld got %d, want %d", got.MonthsWithheld, tc.expectMonths)
+			}
+			if testutils.Abs(got.MonthlyBenefitAfterFRA-tc.expectAfterFRA) > 0.01 {
+				t.Errorf("benefit after FRA got %.2f, want %.2f", got.MonthlyBenefitAfterFRA, tc.expectAfterFRA)
+			}
+		})
+	}
+}
+
+func TestSocialSecurityAppliesEarningsTest(t *testing.T) {
+	got := calculation.CalculateSocialSecurity(models.SocialSecurityCalculationInput{
+		BirthYear:               1963,
+		UserProvidedEstimate62:  1750,
+		UserProvidedEstimateFRA: 2500,
+		UserProvidedEstimate70:  3100,
+		ClaimAge:                62,
+		YearlyEarnedIncome:      []float64{43400},
+	})
+	if testutils.Abs(got.EarningsTest.TotalWithheld-10000) > 0.01 {
+		t.Errorf("withheld got %.2f, want 10000", got.EarningsTest.TotalWithheld)
+	}
+	if !testutils.Contains(got.Notes, "Earnings test") {
+		t.Errorf("notes missing the earnings test: %q", got.Notes)
+	}
+}
+
+func TestTrustFundPayableShare(t *testing.T) {
+	cases := []struct {
+		name      string
+		policy    models.TrustFundPolicy
+		year      int
+		birthYear int
+		expect    float64
+	}{
+		{"No policy", models.TrustFundPolicy{}, 2040, 1965, 1.0},
+		{"Before depletion", models.TrustFundPolicy{DepletionYear: 2034, PayableShare: 0.8}, 2033, 1965, 1.0},
+		{"Uniform share", models.TrustFundPolicy{DepletionYear: 2034, PayableShare: 0.8}, 2034, 1965, 0.8},
+		{"Default share", models.TrustFundPolicy{DepletionYear: 2034}, 2040, 1965, 0.77},
+		{"Cohort share", models.TrustFundPolicy{DepletionYear: 2034, Mode: "cohort", CohortShares: map[int]float64{1950: 1.0}}, 2034, 1950, 1.0},
+		{"Cohort without a share", models.TrustFundPolicy{DepletionYear: 2034, Mode: "cohort", CohortShares: map[int]float64{1950: 1.0}}, 2034, 1965, 0.77},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := calculation.TrustFundPayableShare(tc.policy, tc.year, tc.birthYear)
+			if testutils.Abs(got-tc.expect) > 1e-9 {
+				t.Errorf("share got %.4f, want %.4f", got, tc.expect)
+			}
+		})
+	}
+}
+
+func TestSocialSecurityPayableAfterDepletion(t *testing.T) {
+	got := calculation.CalculateSocialSecurity(models.SocialSecurityCalculationInput{
+		BirthYear:               1963,
+		UserProvidedEstimate62:  1750,
+		UserProvidedEstimateFRA: 2500,
+		UserProvidedEstimate70:  3100,
+		ClaimAge:                67,
+		TrustFund:               models.TrustFundPolicy{DepletionYear: 2034, PayableShare: 0.77},
+	})
+	if testutils.Abs(got.PayableAmount-1925) > 0.01 || got.PayableFromYear != 2034 {
+		t.Errorf("payable got %.2f from %d, want 1925 from 2034", got.PayableAmount, got.PayableFromYear)
+	}
+	if testutils.Abs(got.ClaimingAmount-2500) > 0.01 {
+		t.Errorf("claiming amount got %.2f, want the scheduled 2500", got.ClaimingAmount)
+	}
+	if !testutils.Contains(got.Notes, "Trust fund shortfall") {
+		t.Errorf("notes missing the trust fund shortfall: %q", got.Notes)
+	}
+}
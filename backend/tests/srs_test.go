@@ -75,6 +75,21 @@ func TestSRSCalculation(t *testing.T) {
 			earningsReduction: 0,
 			isEligible:        false,
 		},
+		{
+			name: "Not eligible: MRA+10 classification overrides flag",
+			input: models.SRSCalculationInput{
+				EstimatedSocialSecurityAt62: 18000,
+				YearsOfFERSService:          15,
+				RetirementAge:               57,
+				MRA:                         57,
+				IsImmediateUnreducedAnnuity: true,
+				RetirementYear:              2025,
+				RetirementType:              "MRA+10",
+			},
+			expect:            0,
+			earningsReduction: 0,
+			isEligible:        false,
+		},
 		{
 			name: "Earnings test wipes out SRS",
 			input: models.SRSCalculationInput{
@@ -107,3 +122,91 @@ func TestSRSCalculation(t *testing.T) {
 		})
 	}
 }
+
+func TestSRSYearlySchedule(t *testing.T) {
+	base := models.SRSCalculationInput{
+		EstimatedSocialSecurityAt62: 16000,
+		YearsOfFERSService:          30,
+		RetirementAge:               60,
+		RetirementType:              "60+20",
+		BirthYear:                   1965,
+		BirthMonth:                  6,
+		RetirementYear:              2025,
+		RetirementMonth:             6,
+		YearlyEarnedIncome:          []float64{0, 30000, 0},
+		EarningsLimitGrowthRate:     0.03,
+	}
+	got := calculation.CalculateSRS(base)
+	// $12,000 a year from July 2025 through June 2027 (the month of turning 62)
+	expect := []struct {
+		year      int
+		months    int
+		paid      float64
+		limit     float64
+		reduction float64
+	}{
+		{2025, 6, 6000, 23400, 0},
+		{2026, 12, 12000 - (30000-24120)/2.0, 24120, (30000 - 24120) / 2.0},
+		{2027, 6, 6000, 24840, 0},
+	}
+	if len(got.YearlySRS) != len(expect) {
+		t.Fatalf("schedule length got %d, want %d", len(got.YearlySRS), len(expect))
+	}
+	for i, e := range expect {
+		y := got.YearlySRS[i]
+		if y.Year != e.year || y.Months != e.months {
+			t.Errorf("row %d: got %d with %d months, want %d with %d months", i, y.Year, y.Months, e.year, e.months)
+		}
+		if testutils.Abs(y.EarningsLimit-e.limit) > 0.01 {
+			t.Errorf("%d: earnings limit got %.2f, want %.2f", e.year, y.EarningsLimit, e.limit)
+		}
+		if testutils.Abs(y.EarningsTestReduction-e.reduction) > 0.01 {
+			t.Errorf("%d: reduction got %.2f, want %.2f", e.year, y.EarningsTestReduction, e.reduction)
+		}
+		if testutils.Abs(y.Paid-e.paid) > 0.01 {
+			t.Errorf("%d: paid got %.2f, want %.2f", e.year, y.Paid, e.paid)
+		}
+	}
+}
+
+func TestSRSScheduleVERAStartsAtMRA(t *testing.T) {
+	got := calculation.CalculateSRS(models.SRSCalculationInput{
+		EstimatedSocialSecurityAt62: 16000,
+		YearsOfFERSService:          25,
+		RetirementAge:               55,
+		RetirementType:              "VERA",
+		BirthYear:                   1970,
+		BirthMonth:                  3,
+		RetirementYear:              2025,
+		RetirementMonth:             1,
+	})
+	// MRA of 57 is reached in March 2027; payments start in April
+	if len(got.YearlySRS) == 0 || got.YearlySRS[0].Year != 2027 || got.YearlySRS[0].Months != 9 {
+		t.Fatalf("first SRS year got %+v, want 2027 with 9 months", got.YearlySRS)
+	}
+}
+
+func TestSRSScheduleSpecialCategoryExemptBeforeMRA(t *testing.T) {
+	got := calculation.CalculateSRS(models.SRSCalculationInput{
+		EstimatedSocialSecurityAt62: 16000,
+		YearsOfFERSService:          25,
+		RetirementAge:               50,
+		RetirementType:              "60+20",
+		BirthYear:                   1975,
+		BirthMonth:                  7,
+		RetirementYear:              2025,
+		RetirementMonth:             7,
+		ProjectedEarnedIncome:       50000,
+		IsSpecialCategory:           true,
+	})
+	for _, y := range got.YearlySRS {
+		switch {
+		case y.Year < 2032 && y.EarningsTestReduction != 0:
+			t.Errorf("%d: earnings test applied before MRA", y.Year)
+		case y.Year == 2032 && testutils.Abs(y.EarnedIncome-25000) > 0.01:
+			t.Errorf("2032: earnings counted got %.2f, want 25000.00 (from MRA in July)", y.EarnedIncome)
+		case y.Year > 2032 && y.EarningsTestReduction == 0:
+			t.Errorf("%d: earnings test not applied after MRA", y.Year)
+		}
+	}
+}
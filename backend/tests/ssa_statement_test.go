@@ -0,0 +1,69 @@
+package tests
+
+import (
+	"ferex/backend/calculation"
+	"ferex/backend/tests/testutils"
+	"testing"
+)
+
+const ssaStatementXML = `<?xml version="1.0" encoding="UTF-8"?>
+<osss:OnlineSocialSecurityStatementData xmlns:osss="http://ssa.gov/osss/schemas/2.0">
+  <osss:FileCreationDate>2025-03-01</osss:FileCreationDate>
+  <osss:UserInformation>
+    <osss:Name>Jane Q Public</osss:Name>
+    <osss:DateOfBirth>1965-06-15</osss:DateOfBirth>
+  </osss:UserInformation>
+  <osss:EstimatedBenefits>
+    <osss:EarlyRetirement><osss:RetirementAge>62</osss:RetirementAge><osss:EstimatedBenefitAmount>1750</osss:EstimatedBenefitAmount></osss:EarlyRetirement>
+    <osss:FullRetirement><osss:RetirementAge>67</osss:RetirementAge><osss:EstimatedBenefitAmount>2500</osss:EstimatedBenefitAmount></osss:FullRetirement>
+    <osss:DelayedRetirement><osss:RetirementAge>70</osss:RetirementAge><osss:EstimatedBenefitAmount>3100</osss:EstimatedBenefitAmount></osss:DelayedRetirement>
+  </osss:EstimatedBenefits>
+  <osss:EarningsRecord>
+    <osss:Earnings startYear="2020" endYear="2020"><osss:FicaEarnings>61000</osss:FicaEarnings><osss:MedicareEarnings>61000</osss:MedicareEarnings></osss:Earnings>
+    <osss:Earnings startYear="2018" endYear="2018"><osss:FicaEarnings>55000</osss:FicaEarnings><osss:MedicareEarnings>55000</osss:MedicareEarnings></osss:Earnings>
+    <osss:Earnings startYear="2021" endYear="2021"><osss:FicaEarnings>0</osss:FicaEarnings><osss:MedicareEarnings>0</osss:MedicareEarnings></osss:Earnings>
+    <osss:Earnings startYear="2022" endYear="2022"><osss:FicaEarnings>147000</osss:FicaEarnings><osss:MedicareEarnings>180000</osss:MedicareEarnings></osss:Earnings>
+    <osss:Earnings startYear="2023" endYear="2023"><osss:FicaEarnings>-1</osss:FicaEarnings><osss:MedicareEarnings>-1</osss:MedicareEarnings></osss:Earnings>
+  </osss:EarningsRecord>
+</osss:OnlineSocialSecurityStatementData>`
+
+func TestImportSSAStatement(t *testing.T) {
+	got, err := calculation.ImportSSAStatement([]byte(ssaStatementXML))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Input.BirthYear != 1965 || got.Input.BirthMonth != 6 {
+		t.Errorf("birth year and month got %d/%d, want 1965/6", got.Input.BirthYear, got.Input.BirthMonth)
+	}
+	if got.Input.UserProvidedEstimate62 != 1750 || got.Input.UserProvidedEstimateFRA != 2500 || got.Input.UserProvidedEstimate70 != 3100 {
+		t.Errorf("estimates got %.0f/%.0f/%.0f, want 1750/2500/3100", got.Input.UserProvidedEstimate62, got.Input.UserProvidedEstimateFRA, got.Input.UserProvidedEstimate70)
+	}
+	// History runs 2018-2022 in order, with 2019 missing and 2021 zero filled in as gaps
+	want := []float64{55000, 0, 61000, 0, 147000}
+	if got.Input.EarningsStartYear != 2018 || len(got.Input.EarningsHistory) != len(want) {
+		t.Fatalf("history got start %d with %v, want start 2018 with %v", got.Input.EarningsStartYear, got.Input.EarningsHistory, want)
+	}
+	for i := range want {
+		if got.Input.EarningsHistory[i] != want[i] {
+			t.Errorf("history[%d] got %.0f, want %.0f", i, got.Input.EarningsHistory[i], want[i])
+		}
+	}
+	if len(got.GapYears) != 2 || got.GapYears[0] != 2019 || got.GapYears[1] != 2021 {
+		t.Errorf("gap years got %v, want [2019 2021]", got.GapYears)
+	}
+	if len(got.UnpostedYears) != 1 || got.UnpostedYears[0] != 2023 {
+		t.Errorf("unposted years got %v, want [2023]", got.UnpostedYears)
+	}
+	if len(got.Earnings) != 5 || got.Earnings[0].Year != 2018 || got.Earnings[3].MedicareEarnings != 180000 {
+		t.Errorf("earnings by year not kept in order: %+v", got.Earnings)
+	}
+	if !testutils.Contains(got.Notes, "2019 2021") {
+		t.Errorf("notes should flag the gap years: %q", got.Notes)
+	}
+}
+
+func TestImportSSAStatementRejectsOtherXML(t *testing.T) {
+	if _, err := calculation.ImportSSAStatement([]byte(`<scenario><name>x</name></scenario>`)); err == nil {
+		t.Error("expected an error for a file that is not an SSA earnings record")
+	}
+}
@@ -24,8 +24,8 @@ func TestSurvivorBenefitCalculation(t *testing.T) {
 				COLARate:         0.02,
 				YearsToProject:   3,
 			},
-			expectInitial: 40000 * 0.9 * 0.5, // 10% reduction, 50% to survivor
-			expectTotal:   0,                 // Checked relatively
+			expectInitial: 40000 * 0.5, // 50% of the unreduced annuity to survivor
+			expectTotal:   0,           // Checked relatively
 			notesContains: "FERS max",
 		},
 		{
@@ -41,10 +41,63 @@ func TestSurvivorBenefitCalculation(t *testing.T) {
 				IncludeTSP:        true,
 				TSPBalanceAtDeath: 10000,
 			},
-			expectInitial: 60000 * 0.95 * 0.5,
+			expectInitial: 30000 * 0.55, // No base given: half the annuity
 			expectTotal:   0,
 			notesContains: "CSRS partial",
 		},
+		{
+			name: "FERS insurable interest, beneficiary 12 years younger",
+			input: models.SurvivorBenefitCalculationInput{
+				PensionType:             "FERS",
+				InitialAnnuity:          40000,
+				SurvivorElection:        "insurable interest",
+				BeneficiaryYearsYounger: 12,
+				YearsToProject:          1,
+			},
+			expectInitial: 40000 * 0.80 * 0.50, // 20% reduction, 50% of reduced annuity
+			expectTotal:   0,
+			notesContains: "20% reduction",
+		},
+		{
+			name: "CSRS insurable interest, beneficiary 31 years younger",
+			input: models.SurvivorBenefitCalculationInput{
+				PensionType:             "CSRS",
+				InitialAnnuity:          50000,
+				SurvivorElection:        "insurable interest",
+				BeneficiaryYearsYounger: 31,
+				YearsToProject:          1,
+			},
+			expectInitial: 50000 * 0.60 * 0.55, // 40% reduction, 55% of reduced annuity
+			expectTotal:   0,
+			notesContains: "40% reduction",
+		},
+		{
+			name: "CSRS partial survivor with chosen base",
+			input: models.SurvivorBenefitCalculationInput{
+				PensionType:        "CSRS",
+				InitialAnnuity:     60000,
+				SurvivorElection:   "partial",
+				SurvivorBaseAmount: 12000,
+				COLARate:           0.02,
+				YearsToProject:     2,
+			},
+			expectInitial: 12000 * 0.55,
+			expectTotal:   0,
+			notesContains: "$12000.00 base",
+		},
+		{
+			name: "CSRS max survivor",
+			input: models.SurvivorBenefitCalculationInput{
+				PensionType:      "CSRS",
+				InitialAnnuity:   60000,
+				SurvivorElection: "max",
+				COLARate:         0.02,
+				YearsToProject:   2,
+			},
+			expectInitial: 60000 * 0.55,
+			expectTotal:   0,
+			notesContains: "2.5% of first $3,600",
+		},
 		{
 			name: "FERS no survivor benefit",
 			input: models.SurvivorBenefitCalculationInput{
@@ -75,3 +128,99 @@ func TestSurvivorBenefitCalculation(t *testing.T) {
 		})
 	}
 }
+
+func TestInsurableInterestReductionTable(t *testing.T) {
+	cases := []struct {
+		yearsYounger int
+		reduction    float64
+	}{
+		{0, 0.10}, {4, 0.10}, {5, 0.15}, {9, 0.15}, {10, 0.20}, {15, 0.25},
+		{20, 0.30}, {25, 0.35}, {29, 0.35}, {30, 0.40}, {45, 0.40},
+	}
+	for _, tc := range cases {
+		got := calculation.CalculateSurvivorBenefit(models.SurvivorBenefitCalculationInput{
+			PensionType:             "FERS",
+			InitialAnnuity:          10000,
+			SurvivorElection:        "insurable interest",
+			BeneficiaryYearsYounger: tc.yearsYounger,
+		})
+		if testutils.Abs(got.SurvivorReduction-10000*tc.reduction) > 0.01 {
+			t.Errorf("%d years younger: reduction got %.2f, want %.2f", tc.yearsYounger, got.SurvivorReduction, 10000*tc.reduction)
+		}
+		if testutils.Abs(got.ReducedAnnuity-10000*(1-tc.reduction)) > 0.01 {
+			t.Errorf("%d years younger: reduced annuity got %.2f, want %.2f", tc.yearsYounger, got.ReducedAnnuity, 10000*(1-tc.reduction))
+		}
+	}
+}
+
+func TestFERSInsurableInterestElection(t *testing.T) {
+	got := calculation.CalculateFERSPension(models.FERSCalculationInput{
+		High3Salary:             100000,
+		YearsOfService:          30,
+		AgeAtRetirement:         62,
+		BirthYear:               1962,
+		BirthMonth:              1,
+		SurvivorBenefitElection: "insurable interest",
+		BeneficiaryAge:          45,
+	})
+	// 1.1% x 30 x 100000 = 33000; 17 years younger -> 25% reduction
+	if testutils.Abs(got.AnnualPension-33000*0.75) > 0.01 {
+		t.Errorf("annual pension got %.2f, want %.2f", got.AnnualPension, 33000*0.75)
+	}
+	if testutils.Abs(got.SurvivorAnnuity-33000*0.75*0.5) > 0.01 {
+		t.Errorf("survivor annuity got %.2f, want %.2f", got.SurvivorAnnuity, 33000*0.75*0.5)
+	}
+}
+
+func TestInsurableInterestRequiresBeneficiaryAge(t *testing.T) {
+	fers := calculation.CalculateFERSPension(models.FERSCalculationInput{
+		High3Salary:             100000,
+		YearsOfService:          30,
+		AgeAtRetirement:         62,
+		BirthYear:               1962,
+		BirthMonth:              1,
+		SurvivorBenefitElection: "insurable interest",
+	})
+	csrs := calculation.CalculateCSRS(models.CSRSCalculationInput{
+		High3Salary:             100000,
+		YearsOfService:          30,
+		AgeAtRetirement:         60,
+		SurvivorBenefitElection: "insurable interest",
+	})
+	// Without the beneficiary's age neither annuity is reduced, and the notes say why
+	if testutils.Abs(fers.AnnualPension-33000) > 0.01 || fers.SurvivorAnnuity != 0 {
+		t.Errorf("FERS: annual pension %.2f, survivor annuity %.2f, want 33000 and 0", fers.AnnualPension, fers.SurvivorAnnuity)
+	}
+	if testutils.Abs(csrs.AnnualPension-56250) > 0.01 || csrs.SurvivorAnnuity != 0 {
+		t.Errorf("CSRS: annual pension %.2f, survivor annuity %.2f, want 56250 and 0", csrs.AnnualPension, csrs.SurvivorAnnuity)
+	}
+	for _, notes := range []string{fers.Notes, csrs.Notes} {
+		if !testutils.Contains(notes, "beneficiary's age is required") {
+			t.Errorf("notes missing the beneficiary age requirement: %s", notes)
+		}
+	}
+}
+
+func TestFERSPensionSurvivorAnnuityUsesUnreducedAnnuity(t *testing.T) {
+	cases := []struct {
+		election       string
+		expectSurvivor float64
+	}{
+		{"max", 30000 * 0.50},
+		{"partial", 30000 * 0.25},
+	}
+	for _, tc := range cases {
+		t.Run(tc.election, func(t *testing.T) {
+			got := calculation.CalculateFERSPension(models.FERSCalculationInput{
+				High3Salary:             100000,
+				YearsOfService:          30,
+				AgeAtRetirement:         60,
+				BirthYear:               1965,
+				SurvivorBenefitElection: tc.election,
+			})
+			if testutils.Abs(got.SurvivorAnnuity-tc.expectSurvivor) > 0.01 {
+				t.Errorf("survivor annuity got %.2f, want %.2f", got.SurvivorAnnuity, tc.expectSurvivor)
+			}
+		})
+	}
+}
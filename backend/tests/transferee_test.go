@@ -0,0 +1,117 @@
+package tests
+
+import (
+	"ferex/backend/calculation"
+	"ferex/backend/models"
+	"ferex/backend/tests/testutils"
+	"testing"
+)
+
+func TestFERSTransfereeCalculation(t *testing.T) {
+	cases := []struct {
+		name           string
+		input          models.FERSTransfereeCalculationInput
+		expectCSRS     float64
+		expectFERS     float64
+		expectTotal    float64
+		expectSurvivor float64
+		expectType     string
+	}{
+		{
+			name: "60+20 transferee, no survivor",
+			input: models.FERSTransfereeCalculationInput{
+				High3Salary:             100000,
+				CSRSYearsOfService:      10,
+				FERSYearsOfService:      20,
+				AgeAtRetirement:         60,
+				BirthYear:               1965,
+				SurvivorBenefitElection: "none",
+			},
+			expectCSRS:  100000 * (5*0.015 + 5*0.0175), // $16,250
+			expectFERS:  100000 * 20 * 0.01,            // $20,000
+			expectTotal: 36250,
+			expectType:  "60+20",
+		},
+		{
+			name: "62+5 transferee with max survivor and 1.1% FERS component",
+			input: models.FERSTransfereeCalculationInput{
+				High3Salary:             100000,
+				CSRSYearsOfService:      10,
+				FERSYearsOfService:      15,
+				AgeAtRetirement:         62,
+				BirthYear:               1963,
+				SurvivorBenefitElection: "max",
+			},
+			expectCSRS:     16250 - (90 + 1265), // 2.5% of first $3,600 + 10% of the rest
+			expectFERS:     100000 * 15 * 0.011 * 0.9,
+			expectTotal:    16250 - 1355 + 16500*0.9,
+			expectSurvivor: 16250*0.55 + 16500*0.5,
+			expectType:     "62+5",
+		},
+		{
+			name: "Service history split at the transfer date, sick leave hours on the CSRS component",
+			input: models.FERSTransfereeCalculationInput{
+				High3Salary:          100000,
+				UnusedSickLeaveHours: 1044, // 6 months
+				ServiceHistory: []models.ServicePeriod{
+					{StartDate: date(1985, 1, 1), EndDate: date(2014, 12, 31), ServiceType: "fulltime"},
+				},
+				TransferDate:            date(1995, 1, 1),
+				AgeAtRetirement:         60,
+				BirthYear:               1965,
+				SurvivorBenefitElection: "none",
+			},
+			expectCSRS:  100000 * (5*0.015 + 5*0.0175 + 0.5*0.02), // 10.5 years: $17,250
+			expectFERS:  100000 * 20 * 0.01,                       // $20,000
+			expectTotal: 37250,
+			expectType:  "60+20",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := calculation.CalculateFERSTransferee(tc.input)
+			if testutils.Abs(got.CSRSComponent.AnnualAnnuity-tc.expectCSRS) > 0.01 {
+				t.Errorf("%s: CSRS component got %.2f, want %.2f", tc.name, got.CSRSComponent.AnnualAnnuity, tc.expectCSRS)
+			}
+			if testutils.Abs(got.FERSComponent.AnnualAnnuity-tc.expectFERS) > 0.01 {
+				t.Errorf("%s: FERS component got %.2f, want %.2f", tc.name, got.FERSComponent.AnnualAnnuity, tc.expectFERS)
+			}
+			if testutils.Abs(got.AnnualPension-tc.expectTotal) > 0.01 {
+				t.Errorf("%s: total got %.2f, want %.2f", tc.name, got.AnnualPension, tc.expectTotal)
+			}
+			if testutils.Abs(got.SurvivorAnnuity-tc.expectSurvivor) > 0.01 {
+				t.Errorf("%s: survivor annuity got %.2f, want %.2f", tc.name, got.SurvivorAnnuity, tc.expectSurvivor)
+			}
+			if got.RetirementType != tc.expectType {
+				t.Errorf("%s: type got %q, want %q", tc.name, got.RetirementType, tc.expectType)
+			}
+		})
+	}
+}
+
+func TestFERSTransfereeComponentCOLA(t *testing.T) {
+	got := calculation.CalculateFERSTransferee(models.FERSTransfereeCalculationInput{
+		High3Salary:        100000,
+		CSRSYearsOfService: 10,
+		FERSYearsOfService: 20,
+		AgeAtRetirement:    60,
+		BirthYear:          1965,
+		COLARate:           0.03,
+		ProjectionYears:    4,
+	})
+	csrs := got.CSRSComponent.ProjectedAmounts
+	fers := got.FERSComponent.ProjectedAmounts
+	if len(csrs) != 4 || len(fers) != 4 {
+		t.Fatalf("expected 4 projected years, got %d and %d", len(csrs), len(fers))
+	}
+	if testutils.Abs(csrs[2]-16250*1.03*1.03) > 0.01 {
+		t.Errorf("CSRS component should get full COLA from year one, got %.2f", csrs[2])
+	}
+	if fers[2] != 20000 {
+		t.Errorf("FERS component should get no COLA before 62, got %.2f", fers[2])
+	}
+	if testutils.Abs(fers[3]-20000*1.02) > 0.01 {
+		t.Errorf("FERS component should get the diet COLA after 62, got %.2f", fers[3])
+	}
+}